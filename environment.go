@@ -3,12 +3,15 @@ package environment
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/grafana/xk6-environment/pkg/environment"
 	"github.com/grafana/xk6-environment/pkg/fs"
 	"github.com/grafana/xk6-environment/pkg/kubernetes"
+	"github.com/grafana/xk6-environment/pkg/provider"
 
+	"github.com/sirupsen/logrus"
 	"go.k6.io/k6/js/modules"
 )
 
@@ -33,34 +36,67 @@ type goModuleImpl struct {
 
 var _ goModule = (*goModuleImpl)(nil)
 
-func (mod *goModuleImpl) newEnvironment(params interface{}) (goEnvironment, error) {
-	// the only implementation supported now is vcluster so
-	// omitting the parameter here for simplicity
-	name, _, initFolder, err := processParams(params)
+// newEnvironment constructs Environment. Invalid parameters are always
+// thrown as JS exception, since there is no Environment to return.
+func (mod *goModuleImpl) newEnvironment(paramsArg interface{}) (goEnvironment, error) {
+	params, err := processParams(paramsArg)
 	if err != nil {
-		return nil, err
+		throw(mod.vu.Runtime(), err)
 	}
 
-	// the folder might be empty so skip it
-	// TODO: add some logging here?
-	fenv, err := fs.FindEnv(initFolder)
+	// the environment is created even if the folder can't be read
+	fenv, err := fs.FindEnv(params.initFolder)
+	if logger := mod.logger(); err != nil && logger != nil {
+		logger.WithError(err).Warnf("unable to read init folder %s", params.initFolder)
+	}
+
+	env, err := environment.NewEnvironment(params.implementation, provider.Params{
+		Name:       params.name,
+		ConfigPath: params.kubeconfig,
+		Options:    params.options,
+		Logger:     mod.logger(),
+	}, fenv, nil)
 	if err != nil {
-		fmt.Println("FindTest: ", err)
+		throw(mod.vu.Runtime(), err)
 	}
 
-	env := environment.NewEnvironment(fenv, nil)
 	env.JSOptions = environment.JSOptions{
-		Source: initFolder,
+		Source: params.initFolder,
 	}
+	env.LogEvents = params.logEvents
 
-	env.SetTestName(name)
+	envMetrics, err := registerMetrics(mod.vu)
+	if err != nil {
+		throw(mod.vu.Runtime(), err)
+	}
 
 	return goEnvironmentImpl{
-		e:  env,
-		vu: mod.vu,
+		e:           env,
+		vu:          mod.vu,
+		throwErrors: params.throwErrors,
+		metrics:     envMetrics,
 	}, nil
 }
 
+// logger returns the k6 logger, if it's available.
+func (mod *goModuleImpl) logger() logrus.FieldLogger {
+	return vuLogger(mod.vu)
+}
+
+// vuLogger returns the k6 logger of the VU, in init context or not,
+// if it's available.
+func vuLogger(vu modules.VU) logrus.FieldLogger {
+	if initEnv := vu.InitEnv(); initEnv != nil {
+		return initEnv.Logger
+	}
+
+	if state := vu.State(); state != nil {
+		return state.Logger
+	}
+
+	return nil
+}
+
 func (mod *goModuleImpl) defaultEnvironmentGetter() (goEnvironment, error) {
 	return mod.goEnvironment, nil
 }
@@ -68,127 +104,701 @@ func (mod *goModuleImpl) defaultEnvironmentGetter() (goEnvironment, error) {
 type goEnvironmentImpl struct {
 	e  *environment.Environment
 	vu modules.VU
+
+	// throwErrors makes methods throw errors as JS exceptions
+	// instead of returning them as strings
+	throwErrors bool
+
+	// metrics are nil if the environment was constructed outside of init context
+	metrics *envMetrics
 }
 
 var _ goEnvironment = (*goEnvironmentImpl)(nil)
 
+// result converts the outcome of a method into its JS result: nil on success,
+// otherwise the error is returned as string or, with throwErrors enabled, thrown.
+func (impl goEnvironmentImpl) result(err error) interface{} {
+	if err == nil {
+		return nil
+	}
+
+	if impl.throwErrors {
+		throw(impl.vu.Runtime(), err)
+	}
+
+	return err.Error()
+}
+
 // initMethod is the go representation of the create method.
-//
-//nolint:nilnil,nilerr
 func (impl goEnvironmentImpl) initMethod() (interface{}, error) {
-	if err := impl.e.Create(impl.vu.Context()); err != nil {
-		return err.Error(), nil
-	}
+	m := impl.measure("init")
+	err := impl.e.Create(impl.vu.Context())
+	impl.record(m, err)
 
-	return nil, nil
+	return impl.result(err), nil
 }
 
 // deleteMethod is the go representation of the delete method.
-//
-//nolint:nilnil,nilerr
 func (impl goEnvironmentImpl) deleteMethod() (interface{}, error) {
-	if err := impl.e.Delete(impl.vu.Context()); err != nil {
-		return err.Error(), nil
-	}
+	m := impl.measure("delete")
+	err := impl.e.Delete(impl.vu.Context())
+	impl.record(m, err)
 
-	return nil, nil
+	return impl.result(err), nil
 }
 
 // applyMethod is the go representation of the apply method.
-//
-//nolint:nilnil,nilerr
 func (impl goEnvironmentImpl) applyMethod(fileArg string) (interface{}, error) {
-	if err := impl.e.Apply(impl.vu.Context(), fileArg); err != nil {
-		return err.Error(), nil
+	m := impl.measure("apply")
+	spec, err := environment.ReadManifest(fileArg)
+	if err == nil {
+		impl.tagKinds(m, spec)
+		err = impl.e.ApplySpec(impl.vu.Context(), spec)
 	}
+	impl.record(m, err)
 
-	return nil, nil
+	return impl.result(err), nil
 }
 
 // applySpecMethod is the go representation of the applySpec method.
-//
-//nolint:nilnil,nilerr
 func (impl goEnvironmentImpl) applySpecMethod(specArg string) (interface{}, error) {
-	if err := impl.e.ApplySpec(impl.vu.Context(), specArg); err != nil {
-		return err.Error(), nil
-	}
+	m := impl.measure("applySpec")
+	impl.tagKinds(m, specArg)
+	err := impl.e.ApplySpec(impl.vu.Context(), specArg)
+	impl.record(m, err)
 
-	return nil, nil
+	return impl.result(err), nil
 }
 
-//nolint:nilnil,nilerr
+// waitMethod is the go representation of the wait method.
 func (impl goEnvironmentImpl) waitMethod(conditionArg interface{}, optsArg interface{}) (interface{}, error) {
 	wc, err := kubernetes.NewWaitCondition(conditionArg)
 	if err != nil {
 		// this is a syntax error in definition of condition itself
-		return err.Error(), nil
+		return impl.result(err), nil
 	}
 
 	if optsArg != nil {
-		interval, timeout, err := waitOptions(optsArg)
+		interval, timeout, err := waitOptions("wait", optsArg)
 		if err != nil {
 			// this is a syntax error in options
-			return err.Error(), nil
+			return impl.result(err), nil
 		}
 		wc.TimeParams(interval, timeout)
 	}
 
 	wc.Build()
 
-	if err := impl.e.Wait(impl.vu.Context(), wc); err != nil {
-		return err.Error(), nil
-	}
+	m := impl.measureWait("wait", wc)
+	err = impl.e.Wait(impl.vu.Context(), wc)
+	impl.record(m, err)
 
-	return nil, nil
+	return impl.result(err), nil
 }
 
+// getNMethod is the go representation of the getN method.
 func (impl goEnvironmentImpl) getNMethod(typeArg string, optsArg interface{}) (float64, error) {
-	if typeArg != "pods" {
-		// TODO remove this once error propagation works
-		fmt.Println("got error: only pods are currently supported")
+	n, err := impl.getN(typeArg, optsArg)
+	if err != nil {
+		if impl.throwErrors {
+			throw(impl.vu.Runtime(), err)
+		}
+
+		if logger := vuLogger(impl.vu); logger != nil {
+			logger.WithError(err).Warn("getN() failed")
+		}
 		return 0, nil
 	}
-	opts := map[string]interface{}{}
+
+	return float64(n), nil
+}
+
+func (impl goEnvironmentImpl) getN(typeArg string, optsArg interface{}) (int, error) {
+	opts, err := listParams("getN", optsArg)
+	if err != nil {
+		return 0, err
+	}
+
+	return impl.e.GetN(impl.vu.Context(), typeArg, opts)
+}
+
+// listMethod is the go representation of the list method.
+func (impl goEnvironmentImpl) listMethod(kindArg string, optsArg interface{}) (interface{}, error) {
+	opts, err := listParams("list", optsArg)
+	if err != nil {
+		return impl.result(err), nil
+	}
+
+	items, err := impl.e.List(impl.vu.Context(), kindArg, opts)
+	if err != nil {
+		return impl.result(err), nil
+	}
+
+	// array of objects rather than a Go slice, so that array methods work
+	values := make([]interface{}, len(items))
+	for i := range items {
+		values[i] = items[i]
+	}
+
+	return impl.vu.Runtime().NewArray(values...), nil
+}
+
+// getMethod is the go representation of the get method.
+func (impl goEnvironmentImpl) getMethod(resourceArg interface{}) (interface{}, error) {
+	ref, err := resourceParams("get", resourceArg)
+	if err != nil {
+		return impl.result(err), nil
+	}
+
+	obj, err := impl.e.Get(impl.vu.Context(), ref)
+	if err != nil {
+		return impl.result(err), nil
+	}
+
+	return obj, nil
+}
+
+// setStatusMethod is the go representation of the setStatus method.
+func (impl goEnvironmentImpl) setStatusMethod(resourceArg interface{}, statusArg interface{}) (interface{}, error) {
+	ref, err := resourceParams("setStatus", resourceArg)
+	if err != nil {
+		return impl.result(err), nil
+	}
+
+	status, ok := statusArg.(map[string]interface{})
+	if !ok {
+		return impl.result(fmt.Errorf(`%w: 2nd argument in setStatus() must be an object; got: %+v`,
+			environment.ErrValidation, statusArg)), nil
+	}
+
+	return impl.result(impl.e.SetStatus(impl.vu.Context(), ref, status)), nil
+}
+
+// deleteResourceMethod is the go representation of the deleteResource method.
+func (impl goEnvironmentImpl) deleteResourceMethod(resourceArg interface{}, optsArg interface{}) (interface{}, error) {
+	opts, err := deleteParams("deleteResource", optsArg)
+	if err != nil {
+		return impl.result(err), nil
+	}
+
+	// objects are selected by labels unless name is given
+	if resource, ok := resourceArg.(map[string]interface{}); ok && resource["name"] == nil {
+		kind, _ := resource["kind"].(string)
+		selector := make(map[string]interface{}, len(resource))
+		for k, v := range resource {
+			if k != "kind" {
+				selector[k] = v
+			}
+		}
+
+		// labels are never taken from other keys, so that a typo doesn't select everything
+		if len(kind) == 0 || (selector["labelSelector"] == nil && selector["labels"] == nil) {
+			return impl.result(fmt.Errorf(
+				`%w: deleteResource() expects an object of the form {kind:"Pod",labelSelector:"app=nginx"} `+
+					`or {kind:"Pod",labels:{app:"nginx"}}; got: %+v`,
+				environment.ErrValidation, resourceArg)), nil
+		}
+
+		return impl.result(impl.e.DeleteSelected(impl.vu.Context(), kind, selector, opts)), nil
+	}
+
+	ref, err := resourceParams("deleteResource", resourceArg)
+	if err != nil {
+		return impl.result(err), nil
+	}
+
+	return impl.result(impl.e.DeleteResource(impl.vu.Context(), ref, opts)), nil
+}
+
+// deleteSpecMethod is the go representation of the deleteSpec method.
+func (impl goEnvironmentImpl) deleteSpecMethod(specArg string, optsArg interface{}) (interface{}, error) {
+	opts, err := deleteParams("deleteSpec", optsArg)
+	if err != nil {
+		return impl.result(err), nil
+	}
+
+	return impl.result(impl.e.DeleteSpec(impl.vu.Context(), specArg, opts)), nil
+}
+
+// deleteFileMethod is the go representation of the deleteFile method.
+func (impl goEnvironmentImpl) deleteFileMethod(fileArg string, optsArg interface{}) (interface{}, error) {
+	opts, err := deleteParams("deleteFile", optsArg)
+	if err != nil {
+		return impl.result(err), nil
+	}
+
+	return impl.result(impl.e.DeleteFile(impl.vu.Context(), fileArg, opts)), nil
+}
+
+// patchMethod is the go representation of the patch method.
+func (impl goEnvironmentImpl) patchMethod(
+	resourceArg interface{}, patchArg interface{}, optsArg interface{},
+) (interface{}, error) {
+	ref, err := resourceParams("patch", resourceArg)
+	if err != nil {
+		return impl.result(err), nil
+	}
+
+	opts, ok := optsArg.(map[string]interface{})
+	if optsArg != nil && !ok {
+		return impl.result(fmt.Errorf(
+			`%w: 3rd argument in patch() must be an object of the form {type:"merge",subresource:"status"}; got: %+v`,
+			environment.ErrValidation, optsArg)), nil
+	}
+
+	obj, err := impl.e.Patch(impl.vu.Context(), ref, patchArg, opts)
+	if err != nil {
+		return impl.result(err), nil
+	}
+
+	return obj, nil
+}
+
+// scaleMethod is the go representation of the scale method.
+func (impl goEnvironmentImpl) scaleMethod(
+	kindArg string, nameArg string, namespaceArg string, replicasArg float64,
+) (interface{}, error) {
+	replicas := int32(replicasArg)
+	if float64(replicas) != replicasArg {
+		return impl.result(fmt.Errorf("%w: number of replicas in scale() must be an integer; got: %v",
+			environment.ErrValidation, replicasArg)), nil
+	}
+
+	ref := kubernetes.ObjectRef{Kind: kindArg, Name: nameArg, Namespace: namespaceArg}
+
+	return impl.result(impl.e.Scale(impl.vu.Context(), ref, replicas)), nil
+}
+
+// rolloutRestartMethod is the go representation of the rolloutRestart method.
+func (impl goEnvironmentImpl) rolloutRestartMethod(
+	kindArg string, nameArg string, namespaceArg string,
+) (interface{}, error) {
+	ref := kubernetes.ObjectRef{Kind: kindArg, Name: nameArg, Namespace: namespaceArg}
+
+	return impl.result(impl.e.RolloutRestart(impl.vu.Context(), ref)), nil
+}
+
+// rolloutStatusMethod is the go representation of the rolloutStatus method.
+func (impl goEnvironmentImpl) rolloutStatusMethod(
+	kindArg string, nameArg string, namespaceArg string, optsArg interface{},
+) (interface{}, error) {
+	wc, err := kubernetes.NewRolloutCondition(kindArg, nameArg, namespaceArg)
+	if err != nil {
+		return impl.result(err), nil
+	}
+
 	if optsArg != nil {
-		var ok bool
-		opts, ok = optsArg.(map[string]interface{})
-		if !ok {
-			err := fmt.Errorf(
-				`2nd argument in getN() must be an object of the form {"namespace":"ns","label": "selector"}, got: %+v`,
-				optsArg)
-			// TODO remove this once error propagation works
-			fmt.Println("got error", err)
-			return 0, nil
+		interval, timeout, err := waitOptions("rolloutStatus", optsArg)
+		if err != nil {
+			return impl.result(err), nil
 		}
+		wc.TimeParams(interval, timeout)
 	}
 
-	n, err := impl.e.GetN(impl.vu.Context(), opts)
+	wc.Build()
+
+	m := impl.measureWait("rolloutStatus", wc)
+	err = impl.e.Wait(impl.vu.Context(), wc)
+	impl.record(m, err)
+
+	return impl.result(err), nil
+}
+
+// execMethod is the go representation of the exec method.
+func (impl goEnvironmentImpl) execMethod(
+	targetArg interface{}, commandArg interface{}, optsArg interface{},
+) (interface{}, error) {
+	namespace, pod, opts, command, err := execParams(targetArg, commandArg, optsArg)
 	if err != nil {
-		// TODO remove this once error propagation works
-		fmt.Println("got error", err)
-		return 0, nil
+		return impl.result(err), nil
 	}
-	return float64(n), nil
+
+	result, err := impl.e.Exec(impl.vu.Context(), namespace, pod, command, opts)
+	if err != nil {
+		return impl.result(err), nil
+	}
+
+	return map[string]interface{}{
+		"stdout":   result.Stdout,
+		"stderr":   result.Stderr,
+		"exitCode": result.ExitCode,
+	}, nil
+}
+
+// logsMethod is the go representation of the logs method.
+func (impl goEnvironmentImpl) logsMethod(targetArg interface{}) (interface{}, error) {
+	namespace, pod, selector, opts, err := logsParams(targetArg)
+	if err != nil {
+		return impl.result(err), nil
+	}
+
+	if len(pod) > 0 {
+		text, err := impl.e.Logs(impl.vu.Context(), namespace, pod, opts)
+		if err != nil {
+			return impl.result(err), nil
+		}
+		return text, nil
+	}
+
+	logs, err := impl.e.SelectedLogs(impl.vu.Context(), namespace, selector, opts)
+
+	return impl.selectedLogs(logs, err), nil
+}
+
+// selectedLogs returns logs of the pods which could be fetched; pods which
+// failed are skipped and their errors are logged. It's an error only if
+// logs of none of the pods could be fetched.
+func (impl goEnvironmentImpl) selectedLogs(logs map[string]string, err error) interface{} {
+	if err == nil {
+		return logs
+	}
+
+	if len(logs) == 0 {
+		return impl.result(err)
+	}
+
+	if logger := vuLogger(impl.vu); logger != nil {
+		logger.WithError(err).Warn("logs() skipped pods")
+	}
+
+	return logs
+}
+
+// portForwardMethod is the go representation of the portForward method.
+func (impl goEnvironmentImpl) portForwardMethod(targetArg interface{}) (interface{}, error) {
+	e := fmt.Errorf(
+		`%w: portForward() expects an object of the form {service:"name",namespace:"ns",port:80} or {pod:"name"}; got: %+v`,
+		environment.ErrValidation, targetArg)
+	target, ok := targetArg.(map[string]interface{})
+	if !ok {
+		return impl.result(e), nil
+	}
+
+	service, _ := target["service"].(string)
+	pod, _ := target["pod"].(string)
+	namespace, _ := target["namespace"].(string)
+	port, _ := target["port"].(int64)
+	// exactly one of them
+	if (len(service) == 0) == (len(pod) == 0) {
+		return impl.result(e), nil
+	}
+
+	url, err := impl.e.PortForward(impl.vu.Context(), namespace, service, pod, int(port))
+	if err != nil {
+		return impl.result(err), nil
+	}
+
+	return url, nil
+}
+
+// copyToMethod is the go representation of the copyTo method.
+func (impl goEnvironmentImpl) copyToMethod(
+	targetArg interface{}, localPathArg string, remotePathArg string, optsArg interface{},
+) (interface{}, error) {
+	namespace, pod, opts, err := copyParams("copyTo", targetArg, optsArg)
+	if err != nil {
+		return impl.result(err), nil
+	}
+
+	return impl.result(impl.e.CopyTo(impl.vu.Context(), namespace, pod, localPathArg, remotePathArg, opts)), nil
+}
+
+// copyFromMethod is the go representation of the copyFrom method.
+func (impl goEnvironmentImpl) copyFromMethod(
+	targetArg interface{}, remotePathArg string, localPathArg string, optsArg interface{},
+) (interface{}, error) {
+	namespace, pod, opts, err := copyParams("copyFrom", targetArg, optsArg)
+	if err != nil {
+		return impl.result(err), nil
+	}
+
+	return impl.result(impl.e.CopyFrom(impl.vu.Context(), namespace, pod, remotePathArg, localPathArg, opts)), nil
+}
+
+// envParams are parameters of Environment, common for all implementations.
+type envParams struct {
+	name, implementation, initFolder, kubeconfig string
+	throwErrors                                  bool
+	// logEvents is nil unless events are logged
+	logEvents *kubernetes.EventFilter
+	// options are all parameters, including those of implementations
+	options map[string]interface{}
 }
 
 // TODO: tygor issue for this boilerplate
-func processParams(paramsArg interface{}) (name, implementation, initFolder string, err error) {
-	e := fmt.Errorf(`Environment() expects an object; got: %+v`, paramsArg)
-	params, ok := paramsArg.(map[string]interface{})
+func processParams(paramsArg interface{}) (params envParams, err error) {
+	e := fmt.Errorf(`%w: Environment() expects an object; got: %+v`, environment.ErrValidation, paramsArg)
+	options, ok := paramsArg.(map[string]interface{})
 	if !ok {
 		err = e
 		return
 	}
 
-	name, _ = params["name"].(string)
-	implementation, _ = params["implementation"].(string)
-	initFolder, _ = params["initFolder"].(string)
+	params.options = options
+	params.name, _ = options["name"].(string)
+	params.implementation = environment.DefaultImplementation
+	if v, ok := options["implementation"]; ok {
+		if params.implementation, ok = v.(string); !ok {
+			err = fmt.Errorf(`%w: "implementation" must be a string; got: %+v`, environment.ErrValidation, v)
+			return
+		}
+	}
+	params.initFolder, _ = options["initFolder"].(string)
+
+	if v, ok := options["kubeconfig"]; ok {
+		if params.kubeconfig, ok = v.(string); !ok {
+			err = fmt.Errorf(`%w: "kubeconfig" must be a path to Kubeconfig; got: %+v`, environment.ErrValidation, v)
+			return
+		}
+	}
+
+	if v, ok := options["throwErrors"]; ok {
+		if params.throwErrors, ok = v.(bool); !ok {
+			err = fmt.Errorf(`%w: "throwErrors" must be a boolean; got: %+v`, environment.ErrValidation, v)
+			return
+		}
+	}
+
+	if v, ok := options["logEvents"]; ok {
+		params.logEvents, err = logEventsParams(v)
+	}
 
 	return
 }
 
-func waitOptions(optsArg interface{}) (interval, timeout time.Duration, err error) {
-	e := fmt.Errorf(`2nd argument in wait() must be an object of the form {interval:"1h",timeout:"5m"}; got: %+v`, optsArg)
+func logEventsParams(v interface{}) (*kubernetes.EventFilter, error) {
+	e := fmt.Errorf(`%w: "logEvents" must be an object of the form {types:["Warning"],namespaces:["default"]}; got: %+v`,
+		environment.ErrValidation, v)
+
+	opts, ok := v.(map[string]interface{})
+	if !ok {
+		return nil, e
+	}
+
+	filter := &kubernetes.EventFilter{}
+	for key, list := range map[string]*[]string{"types": &filter.Types, "namespaces": &filter.Namespaces} {
+		if opts[key] == nil {
+			continue
+		}
+
+		items, ok := opts[key].([]interface{})
+		if !ok {
+			return nil, e
+		}
+
+		for _, item := range items {
+			s, ok := item.(string)
+			if !ok {
+				return nil, e
+			}
+			*list = append(*list, s)
+		}
+	}
+
+	return filter, nil
+}
+
+func listParams(method string, optsArg interface{}) (map[string]interface{}, error) {
+	if optsArg == nil {
+		return map[string]interface{}{}, nil
+	}
+
+	opts, ok := optsArg.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf(
+			`%w: 2nd argument in %s() must be an object of the form {namespace:"ns",labelSelector:"app=nginx"}, got: %+v`,
+			environment.ErrValidation, method, optsArg)
+	}
+
+	return opts, nil
+}
+
+func deleteParams(method string, optsArg interface{}) (map[string]interface{}, error) {
+	if optsArg == nil {
+		return map[string]interface{}{}, nil
+	}
+
+	opts, ok := optsArg.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf(
+			`%w: 2nd argument in %s() must be an object of the form {propagationPolicy:"Foreground",wait:true}, got: %+v`,
+			environment.ErrValidation, method, optsArg)
+	}
+
+	return opts, nil
+}
+
+func resourceParams(method string, resourceArg interface{}) (ref kubernetes.ObjectRef, err error) {
+	e := fmt.Errorf(
+		`%w: %s() expects an object of the form {kind:"Pod",apiVersion:"v1",name:"name",namespace:"ns"}; got: %+v`,
+		environment.ErrValidation, method, resourceArg)
+	resource, ok := resourceArg.(map[string]interface{})
+	if !ok {
+		err = e
+		return
+	}
+
+	ref.APIVersion, _ = resource["apiVersion"].(string)
+	ref.Kind, _ = resource["kind"].(string)
+	ref.Name, _ = resource["name"].(string)
+	ref.Namespace, _ = resource["namespace"].(string)
+
+	if len(ref.Kind) == 0 || len(ref.Name) == 0 {
+		err = e
+	}
+
+	return
+}
+
+func containerParams(method string, targetArg interface{}) (namespace, pod, container string, err error) {
+	target, ok := targetArg.(map[string]interface{})
+	if ok {
+		pod, _ = target["pod"].(string)
+		namespace, _ = target["namespace"].(string)
+		container, _ = target["container"].(string)
+	}
+	if len(pod) == 0 {
+		err = fmt.Errorf(`%w: %s() expects an object of the form {pod:"name",namespace:"ns",container:"c"}; got: %+v`,
+			environment.ErrValidation, method, targetArg)
+	}
+
+	return
+}
+
+func copyParams(
+	method string, targetArg, optsArg interface{},
+) (namespace, pod string, opts kubernetes.CopyOptions, err error) {
+	if namespace, pod, opts.Container, err = containerParams(method, targetArg); err != nil {
+		return
+	}
+
+	if optsArg == nil {
+		return
+	}
+
+	e := fmt.Errorf(`%w: 4th argument in %s() must be an object of the form {maxBytes:1048576,timeout:"1m"}; got: %+v`,
+		environment.ErrValidation, method, optsArg)
+	copyOpts, ok := optsArg.(map[string]interface{})
+	if !ok {
+		err = e
+		return
+	}
+
+	for k, v := range copyOpts {
+		switch k {
+		case "maxBytes":
+			opts.MaxBytes, ok = v.(int64)
+		case "timeout":
+			var timeout string
+			if timeout, ok = v.(string); ok {
+				opts.Timeout, err = time.ParseDuration(timeout)
+				ok = err == nil
+			}
+		default:
+			ok = false
+		}
+
+		if !ok {
+			err = e
+			return
+		}
+	}
+
+	return
+}
+
+func execParams(
+	targetArg, commandArg, optsArg interface{},
+) (namespace, pod string, opts kubernetes.ExecOptions, command []string, err error) {
+	if namespace, pod, opts.Container, err = containerParams("exec", targetArg); err != nil {
+		return
+	}
+
+	args, _ := commandArg.([]interface{})
+	for _, arg := range args {
+		s, ok := arg.(string)
+		if !ok {
+			break
+		}
+		command = append(command, s)
+	}
+	if len(command) == 0 || len(command) != len(args) {
+		err = fmt.Errorf(`%w: 2nd argument in exec() must be a non-empty array of strings, like ["ls","-l"]; got: %+v`,
+			environment.ErrValidation, commandArg)
+		return
+	}
+
+	if optsArg == nil {
+		return
+	}
+
+	execOpts, ok := optsArg.(map[string]interface{})
+	if !ok {
+		err = fmt.Errorf(`%w: 3rd argument in exec() must be an object of the form {stdin:"input",timeout:"1m"}; got: %+v`,
+			environment.ErrValidation, optsArg)
+		return
+	}
+
+	if stdin, ok := execOpts["stdin"].(string); ok {
+		opts.Stdin = strings.NewReader(stdin)
+	}
+
+	if timeout, ok := execOpts["timeout"].(string); ok {
+		if opts.Timeout, err = time.ParseDuration(timeout); err != nil {
+			err = fmt.Errorf("%w: %w", environment.ErrValidation, err)
+		}
+	}
+
+	return
+}
+
+func logsParams(targetArg interface{}) (namespace, pod, selector string, opts kubernetes.LogOptions, err error) {
+	e := fmt.Errorf(
+		`%w: logs() expects an object of the form {pod:"name",namespace:"ns"} or {labelSelector:"app=nginx"}; got: %+v`,
+		environment.ErrValidation, targetArg)
+	target, ok := targetArg.(map[string]interface{})
+	if !ok {
+		err = e
+		return
+	}
+
+	for k, v := range target {
+		switch k {
+		case "pod":
+			pod, ok = v.(string)
+		case "labelSelector":
+			selector, ok = v.(string)
+		case "namespace":
+			namespace, ok = v.(string)
+		case "container":
+			opts.Container, ok = v.(string)
+		case "tailLines":
+			opts.TailLines, ok = v.(int64)
+		case "sinceSeconds":
+			opts.SinceSeconds, ok = v.(int64)
+		case "previous":
+			opts.Previous, ok = v.(bool)
+		default:
+			ok = false
+		}
+
+		if !ok {
+			err = e
+			return
+		}
+	}
+
+	// exactly one of them
+	if (len(pod) == 0) == (len(selector) == 0) {
+		err = e
+	}
+
+	return
+}
+
+func waitOptions(method string, optsArg interface{}) (interval, timeout time.Duration, err error) {
+	e := fmt.Errorf(`%w: options of %s() must be an object of the form {interval:"1h",timeout:"5m"}; got: %+v`,
+		environment.ErrValidation, method, optsArg)
 	opts, ok := optsArg.(map[string]interface{})
 	if !ok {
 		err = e
@@ -199,14 +809,16 @@ func waitOptions(optsArg interface{}) (interval, timeout time.Duration, err erro
 	timeoutS, _ := opts["timeout"].(string)
 
 	if len(intervalS) > 0 {
-		interval, err = time.ParseDuration(intervalS)
-		if err != nil {
+		if interval, err = time.ParseDuration(intervalS); err != nil {
+			err = fmt.Errorf("%w: %w", environment.ErrValidation, err)
 			return
 		}
 	}
 
 	if len(timeoutS) > 0 {
-		timeout, err = time.ParseDuration(timeoutS)
+		if timeout, err = time.ParseDuration(timeoutS); err != nil {
+			err = fmt.Errorf("%w: %w", environment.ErrValidation, err)
+		}
 	}
 
 	return
@@ -126,7 +126,7 @@ type jsEnvironment interface {
 	// applyMethod is the go binding for the JavaScript apply method.
 	//
 	// TSDoc:
-	// apply reads the contents of the file and applies them to the virtual cluster.
+	// apply reads the contents of the file and applies them to the virtual cluster. All documents are applied even if some of them fail; errors refer to the failed documents by their index and object identity.
 	applyMethod(call goja.FunctionCall, vm *goja.Runtime) goja.Value
 
 	// applySpecMethod is the go binding for the JavaScript applySpec method.
@@ -153,6 +153,116 @@ type jsEnvironment interface {
 	// TSDoc:
 	// getN is a substitute for get(), hopefully temporary. See [tygor's](https://github.com/szkiba/tygor) roadmap about support for arrays.
 	getNMethod(call goja.FunctionCall, vm *goja.Runtime) goja.Value
+
+	// setStatusMethod is the go binding for the JavaScript setStatus method.
+	//
+	// TSDoc:
+	// setStatus replaces `.status` of the object within the environment. Normally `.status`
+	// is set by Kubernetes controllers, so this is meant to simulate them, e.g. with the "fake"
+	// implementation.
+	setStatusMethod(call goja.FunctionCall, vm *goja.Runtime) goja.Value
+
+	// getMethod is the go binding for the JavaScript get method.
+	//
+	// TSDoc:
+	// get returns the object within environment, as it is represented in JSON, so that
+	// its spec and status can be checked directly. The object is looked up by kind, name
+	// and namespace, with optional apiVersion: by default, the preferred version of kind is used.
+	getMethod(call goja.FunctionCall, vm *goja.Runtime) goja.Value
+
+	// listMethod is the go binding for the JavaScript list method.
+	//
+	// TSDoc:
+	// list returns objects of the given kind within environment, as they are represented in JSON.
+	listMethod(call goja.FunctionCall, vm *goja.Runtime) goja.Value
+
+	// deleteResourceMethod is the go binding for the JavaScript deleteResource method.
+	//
+	// TSDoc:
+	// deleteResource removes the object within environment, given by kind, name and namespace, or all
+	// objects of the kind selected by labelSelector or labels when name is omitted. Options set propagation
+	// policy of dependents and whether to wait until the objects are gone.
+	deleteResourceMethod(call goja.FunctionCall, vm *goja.Runtime) goja.Value
+
+	// deleteSpecMethod is the go binding for the JavaScript deleteSpec method.
+	//
+	// TSDoc:
+	// deleteSpec removes the objects described by the manifest spec, e.g. those deployed with applySpec.
+	deleteSpecMethod(call goja.FunctionCall, vm *goja.Runtime) goja.Value
+
+	// deleteFileMethod is the go binding for the JavaScript deleteFile method.
+	//
+	// TSDoc:
+	// deleteFile removes the objects described by the manifest file, e.g. those deployed with apply.
+	deleteFileMethod(call goja.FunctionCall, vm *goja.Runtime) goja.Value
+
+	// patchMethod is the go binding for the JavaScript patch method.
+	//
+	// TSDoc:
+	// patch changes the object within environment with a merge, JSON, strategic merge or apply patch
+	// and returns the result. Unlike applySpec, only the patched fields are owned by xk6-environment.
+	// The status and scale subresources can be patched as well.
+	patchMethod(call goja.FunctionCall, vm *goja.Runtime) goja.Value
+
+	// scaleMethod is the go binding for the JavaScript scale method.
+	//
+	// TSDoc:
+	// scale sets the number of replicas of Deployment, StatefulSet, ReplicaSet or any other kind
+	// with the scale subresource.
+	scaleMethod(call goja.FunctionCall, vm *goja.Runtime) goja.Value
+
+	// rolloutRestartMethod is the go binding for the JavaScript rolloutRestart method.
+	//
+	// TSDoc:
+	// rolloutRestart restarts rollout of Deployment or StatefulSet, like `kubectl rollout restart`.
+	rolloutRestartMethod(call goja.FunctionCall, vm *goja.Runtime) goja.Value
+
+	// rolloutStatusMethod is the go binding for the JavaScript rolloutStatus method.
+	//
+	// TSDoc:
+	// rolloutStatus blocks until rollout of Deployment, StatefulSet or ReplicaSet is complete, like
+	// `kubectl rollout status`: the latest spec is observed and all replicas are updated and available.
+	// Pods of StatefulSet are expected to be updated only up to the rolling update partition,
+	// and StatefulSet with `OnDelete` update strategy is not supported.
+	rolloutStatusMethod(call goja.FunctionCall, vm *goja.Runtime) goja.Value
+
+	// execMethod is the go binding for the JavaScript exec method.
+	//
+	// TSDoc:
+	// exec runs the command in a container of the pod, like `kubectl exec`, and returns its output
+	// and exit code as `{stdout, stderr, exitCode}`. Non-zero exit code of the command is not an error.
+	execMethod(call goja.FunctionCall, vm *goja.Runtime) goja.Value
+
+	// logsMethod is the go binding for the JavaScript logs method.
+	//
+	// TSDoc:
+	// logs returns logs of a container of the pod, like `kubectl logs`, or a map of pod name to logs
+	// when pods are selected by labelSelector.
+	// Pods whose logs cannot be fetched are skipped and their errors are logged as warnings;
+	// it fails only if logs of none of the selected pods can be fetched.
+	logsMethod(call goja.FunctionCall, vm *goja.Runtime) goja.Value
+
+	// portForwardMethod is the go binding for the JavaScript portForward method.
+	//
+	// TSDoc:
+	// portForward opens a tunnel to the port of the service or pod, like `kubectl port-forward`, and returns
+	// its local URL. Connections to a service are forwarded to a ready pod of it, which is chosen again
+	// when the pod is gone or not ready. Tunnels are shared by VUs and stay open until the environment is deleted.
+	portForwardMethod(call goja.FunctionCall, vm *goja.Runtime) goja.Value
+
+	// copyToMethod is the go binding for the JavaScript copyTo method.
+	//
+	// TSDoc:
+	// copyTo copies the local file or directory into a container of the pod, like `kubectl cp`,
+	// with tar over exec. Modes of files are preserved.
+	copyToMethod(call goja.FunctionCall, vm *goja.Runtime) goja.Value
+
+	// copyFromMethod is the go binding for the JavaScript copyFrom method.
+	//
+	// TSDoc:
+	// copyFrom copies the file or directory from a container of the pod to the local path, like
+	// `kubectl cp`, with tar over exec. Modes of files are preserved; symbolic links are skipped.
+	copyFromMethod(call goja.FunctionCall, vm *goja.Runtime) goja.Value
 }
 
 // goEnvironment is the go representation of the JavaScript Environment type.
@@ -175,7 +285,7 @@ type goEnvironment interface {
 	// applyMethod is the go representation of the apply method.
 	//
 	// TSDoc:
-	// apply reads the contents of the file and applies them to the virtual cluster.
+	// apply reads the contents of the file and applies them to the virtual cluster. All documents are applied even if some of them fail; errors refer to the failed documents by their index and object identity.
 	applyMethod(fileArg string) (interface{}, error)
 
 	// applySpecMethod is the go representation of the applySpec method.
@@ -202,6 +312,116 @@ type goEnvironment interface {
 	// TSDoc:
 	// getN is a substitute for get(), hopefully temporary. See [tygor's](https://github.com/szkiba/tygor) roadmap about support for arrays.
 	getNMethod(typeArg string, optsArg interface{}) (float64, error)
+
+	// setStatusMethod is the go representation of the setStatus method.
+	//
+	// TSDoc:
+	// setStatus replaces `.status` of the object within the environment. Normally `.status`
+	// is set by Kubernetes controllers, so this is meant to simulate them, e.g. with the "fake"
+	// implementation.
+	setStatusMethod(resourceArg interface{}, statusArg interface{}) (interface{}, error)
+
+	// getMethod is the go representation of the get method.
+	//
+	// TSDoc:
+	// get returns the object within environment, as it is represented in JSON, so that
+	// its spec and status can be checked directly. The object is looked up by kind, name
+	// and namespace, with optional apiVersion: by default, the preferred version of kind is used.
+	getMethod(resourceArg interface{}) (interface{}, error)
+
+	// listMethod is the go representation of the list method.
+	//
+	// TSDoc:
+	// list returns objects of the given kind within environment, as they are represented in JSON.
+	listMethod(kindArg string, optsArg interface{}) (interface{}, error)
+
+	// deleteResourceMethod is the go representation of the deleteResource method.
+	//
+	// TSDoc:
+	// deleteResource removes the object within environment, given by kind, name and namespace, or all
+	// objects of the kind selected by labelSelector or labels when name is omitted. Options set propagation
+	// policy of dependents and whether to wait until the objects are gone.
+	deleteResourceMethod(resourceArg interface{}, optsArg interface{}) (interface{}, error)
+
+	// deleteSpecMethod is the go representation of the deleteSpec method.
+	//
+	// TSDoc:
+	// deleteSpec removes the objects described by the manifest spec, e.g. those deployed with applySpec.
+	deleteSpecMethod(specArg string, optsArg interface{}) (interface{}, error)
+
+	// deleteFileMethod is the go representation of the deleteFile method.
+	//
+	// TSDoc:
+	// deleteFile removes the objects described by the manifest file, e.g. those deployed with apply.
+	deleteFileMethod(fileArg string, optsArg interface{}) (interface{}, error)
+
+	// patchMethod is the go representation of the patch method.
+	//
+	// TSDoc:
+	// patch changes the object within environment with a merge, JSON, strategic merge or apply patch
+	// and returns the result. Unlike applySpec, only the patched fields are owned by xk6-environment.
+	// The status and scale subresources can be patched as well.
+	patchMethod(resourceArg interface{}, patchArg interface{}, optsArg interface{}) (interface{}, error)
+
+	// scaleMethod is the go representation of the scale method.
+	//
+	// TSDoc:
+	// scale sets the number of replicas of Deployment, StatefulSet, ReplicaSet or any other kind
+	// with the scale subresource.
+	scaleMethod(kindArg string, nameArg string, namespaceArg string, replicasArg float64) (interface{}, error)
+
+	// rolloutRestartMethod is the go representation of the rolloutRestart method.
+	//
+	// TSDoc:
+	// rolloutRestart restarts rollout of Deployment or StatefulSet, like `kubectl rollout restart`.
+	rolloutRestartMethod(kindArg string, nameArg string, namespaceArg string) (interface{}, error)
+
+	// rolloutStatusMethod is the go representation of the rolloutStatus method.
+	//
+	// TSDoc:
+	// rolloutStatus blocks until rollout of Deployment, StatefulSet or ReplicaSet is complete, like
+	// `kubectl rollout status`: the latest spec is observed and all replicas are updated and available.
+	// Pods of StatefulSet are expected to be updated only up to the rolling update partition,
+	// and StatefulSet with `OnDelete` update strategy is not supported.
+	rolloutStatusMethod(kindArg string, nameArg string, namespaceArg string, optsArg interface{}) (interface{}, error)
+
+	// execMethod is the go representation of the exec method.
+	//
+	// TSDoc:
+	// exec runs the command in a container of the pod, like `kubectl exec`, and returns its output
+	// and exit code as `{stdout, stderr, exitCode}`. Non-zero exit code of the command is not an error.
+	execMethod(targetArg interface{}, commandArg interface{}, optsArg interface{}) (interface{}, error)
+
+	// logsMethod is the go representation of the logs method.
+	//
+	// TSDoc:
+	// logs returns logs of a container of the pod, like `kubectl logs`, or a map of pod name to logs
+	// when pods are selected by labelSelector.
+	// Pods whose logs cannot be fetched are skipped and their errors are logged as warnings;
+	// it fails only if logs of none of the selected pods can be fetched.
+	logsMethod(targetArg interface{}) (interface{}, error)
+
+	// portForwardMethod is the go representation of the portForward method.
+	//
+	// TSDoc:
+	// portForward opens a tunnel to the port of the service or pod, like `kubectl port-forward`, and returns
+	// its local URL. Connections to a service are forwarded to a ready pod of it, which is chosen again
+	// when the pod is gone or not ready. Tunnels are shared by VUs and stay open until the environment is deleted.
+	portForwardMethod(targetArg interface{}) (interface{}, error)
+
+	// copyToMethod is the go representation of the copyTo method.
+	//
+	// TSDoc:
+	// copyTo copies the local file or directory into a container of the pod, like `kubectl cp`,
+	// with tar over exec. Modes of files are preserved.
+	copyToMethod(targetArg interface{}, localPathArg string, remotePathArg string, optsArg interface{}) (interface{}, error)
+
+	// copyFromMethod is the go representation of the copyFrom method.
+	//
+	// TSDoc:
+	// copyFrom copies the file or directory from a container of the pod to the local path, like
+	// `kubectl cp`, with tar over exec. Modes of files are preserved; symbolic links are skipped.
+	copyFromMethod(targetArg interface{}, remotePathArg string, localPathArg string, optsArg interface{}) (interface{}, error)
 }
 
 // jsEnvironmentAdapter converts goEnvironment to jsEnvironment.
@@ -271,6 +491,156 @@ func (self *jsEnvironmentAdapter) getNMethod(call goja.FunctionCall, vm *goja.Ru
 	return vm.ToValue(v)
 }
 
+// setStatusMethod is a jsEnvironment adapter method.
+func (self *jsEnvironmentAdapter) setStatusMethod(call goja.FunctionCall, vm *goja.Runtime) goja.Value {
+	v, err := self.adaptee.setStatusMethod(call.Argument(0).Export(), call.Argument(1).Export())
+	if err != nil {
+		panic(err)
+	}
+
+	return vm.ToValue(v)
+}
+
+// getMethod is a jsEnvironment adapter method.
+func (self *jsEnvironmentAdapter) getMethod(call goja.FunctionCall, vm *goja.Runtime) goja.Value {
+	v, err := self.adaptee.getMethod(call.Argument(0).Export())
+	if err != nil {
+		panic(err)
+	}
+
+	return vm.ToValue(v)
+}
+
+// listMethod is a jsEnvironment adapter method.
+func (self *jsEnvironmentAdapter) listMethod(call goja.FunctionCall, vm *goja.Runtime) goja.Value {
+	v, err := self.adaptee.listMethod(call.Argument(0).String(), call.Argument(1).Export())
+	if err != nil {
+		panic(err)
+	}
+
+	return vm.ToValue(v)
+}
+
+// deleteResourceMethod is a jsEnvironment adapter method.
+func (self *jsEnvironmentAdapter) deleteResourceMethod(call goja.FunctionCall, vm *goja.Runtime) goja.Value {
+	v, err := self.adaptee.deleteResourceMethod(call.Argument(0).Export(), call.Argument(1).Export())
+	if err != nil {
+		panic(err)
+	}
+
+	return vm.ToValue(v)
+}
+
+// deleteSpecMethod is a jsEnvironment adapter method.
+func (self *jsEnvironmentAdapter) deleteSpecMethod(call goja.FunctionCall, vm *goja.Runtime) goja.Value {
+	v, err := self.adaptee.deleteSpecMethod(call.Argument(0).String(), call.Argument(1).Export())
+	if err != nil {
+		panic(err)
+	}
+
+	return vm.ToValue(v)
+}
+
+// deleteFileMethod is a jsEnvironment adapter method.
+func (self *jsEnvironmentAdapter) deleteFileMethod(call goja.FunctionCall, vm *goja.Runtime) goja.Value {
+	v, err := self.adaptee.deleteFileMethod(call.Argument(0).String(), call.Argument(1).Export())
+	if err != nil {
+		panic(err)
+	}
+
+	return vm.ToValue(v)
+}
+
+// patchMethod is a jsEnvironment adapter method.
+func (self *jsEnvironmentAdapter) patchMethod(call goja.FunctionCall, vm *goja.Runtime) goja.Value {
+	v, err := self.adaptee.patchMethod(call.Argument(0).Export(), call.Argument(1).Export(), call.Argument(2).Export())
+	if err != nil {
+		panic(err)
+	}
+
+	return vm.ToValue(v)
+}
+
+// scaleMethod is a jsEnvironment adapter method.
+func (self *jsEnvironmentAdapter) scaleMethod(call goja.FunctionCall, vm *goja.Runtime) goja.Value {
+	v, err := self.adaptee.scaleMethod(call.Argument(0).String(), call.Argument(1).String(), call.Argument(2).String(), call.Argument(3).ToFloat())
+	if err != nil {
+		panic(err)
+	}
+
+	return vm.ToValue(v)
+}
+
+// rolloutRestartMethod is a jsEnvironment adapter method.
+func (self *jsEnvironmentAdapter) rolloutRestartMethod(call goja.FunctionCall, vm *goja.Runtime) goja.Value {
+	v, err := self.adaptee.rolloutRestartMethod(call.Argument(0).String(), call.Argument(1).String(), call.Argument(2).String())
+	if err != nil {
+		panic(err)
+	}
+
+	return vm.ToValue(v)
+}
+
+// rolloutStatusMethod is a jsEnvironment adapter method.
+func (self *jsEnvironmentAdapter) rolloutStatusMethod(call goja.FunctionCall, vm *goja.Runtime) goja.Value {
+	v, err := self.adaptee.rolloutStatusMethod(call.Argument(0).String(), call.Argument(1).String(), call.Argument(2).String(), call.Argument(3).Export())
+	if err != nil {
+		panic(err)
+	}
+
+	return vm.ToValue(v)
+}
+
+// execMethod is a jsEnvironment adapter method.
+func (self *jsEnvironmentAdapter) execMethod(call goja.FunctionCall, vm *goja.Runtime) goja.Value {
+	v, err := self.adaptee.execMethod(call.Argument(0).Export(), call.Argument(1).Export(), call.Argument(2).Export())
+	if err != nil {
+		panic(err)
+	}
+
+	return vm.ToValue(v)
+}
+
+// logsMethod is a jsEnvironment adapter method.
+func (self *jsEnvironmentAdapter) logsMethod(call goja.FunctionCall, vm *goja.Runtime) goja.Value {
+	v, err := self.adaptee.logsMethod(call.Argument(0).Export())
+	if err != nil {
+		panic(err)
+	}
+
+	return vm.ToValue(v)
+}
+
+// portForwardMethod is a jsEnvironment adapter method.
+func (self *jsEnvironmentAdapter) portForwardMethod(call goja.FunctionCall, vm *goja.Runtime) goja.Value {
+	v, err := self.adaptee.portForwardMethod(call.Argument(0).Export())
+	if err != nil {
+		panic(err)
+	}
+
+	return vm.ToValue(v)
+}
+
+// copyToMethod is a jsEnvironment adapter method.
+func (self *jsEnvironmentAdapter) copyToMethod(call goja.FunctionCall, vm *goja.Runtime) goja.Value {
+	v, err := self.adaptee.copyToMethod(call.Argument(0).Export(), call.Argument(1).String(), call.Argument(2).String(), call.Argument(3).Export())
+	if err != nil {
+		panic(err)
+	}
+
+	return vm.ToValue(v)
+}
+
+// copyFromMethod is a jsEnvironment adapter method.
+func (self *jsEnvironmentAdapter) copyFromMethod(call goja.FunctionCall, vm *goja.Runtime) goja.Value {
+	v, err := self.adaptee.copyFromMethod(call.Argument(0).Export(), call.Argument(1).String(), call.Argument(2).String(), call.Argument(3).Export())
+	if err != nil {
+		panic(err)
+	}
+
+	return vm.ToValue(v)
+}
+
 // goEnvironmentAdapter converts goja Object to goEnvironment.
 type goEnvironmentAdapter struct {
 	adaptee *goja.Object
@@ -369,6 +739,231 @@ func (self *goEnvironmentAdapter) getNMethod(typeArg string, optsArg interface{}
 	return res.ToFloat(), nil
 }
 
+// setStatusMethod is a setStatus adapter method.
+func (self *goEnvironmentAdapter) setStatusMethod(resourceArg interface{}, statusArg interface{}) (interface{}, error) {
+	fun, ok := goja.AssertFunction(self.adaptee.Get("setStatus"))
+	if !ok {
+		return nil, fmt.Errorf("%w: setStatus", errors.ErrUnsupported)
+	}
+
+	res, err := fun(self.adaptee)
+	if err != nil {
+		return nil, err
+	}
+
+	return res.Export(), nil
+}
+
+// getMethod is a get adapter method.
+func (self *goEnvironmentAdapter) getMethod(resourceArg interface{}) (interface{}, error) {
+	fun, ok := goja.AssertFunction(self.adaptee.Get("get"))
+	if !ok {
+		return nil, fmt.Errorf("%w: get", errors.ErrUnsupported)
+	}
+
+	res, err := fun(self.adaptee)
+	if err != nil {
+		return nil, err
+	}
+
+	return res.Export(), nil
+}
+
+// listMethod is a list adapter method.
+func (self *goEnvironmentAdapter) listMethod(kindArg string, optsArg interface{}) (interface{}, error) {
+	fun, ok := goja.AssertFunction(self.adaptee.Get("list"))
+	if !ok {
+		return nil, fmt.Errorf("%w: list", errors.ErrUnsupported)
+	}
+
+	res, err := fun(self.adaptee)
+	if err != nil {
+		return nil, err
+	}
+
+	return res.Export(), nil
+}
+
+// deleteResourceMethod is a deleteResource adapter method.
+func (self *goEnvironmentAdapter) deleteResourceMethod(resourceArg interface{}, optsArg interface{}) (interface{}, error) {
+	fun, ok := goja.AssertFunction(self.adaptee.Get("deleteResource"))
+	if !ok {
+		return nil, fmt.Errorf("%w: deleteResource", errors.ErrUnsupported)
+	}
+
+	res, err := fun(self.adaptee)
+	if err != nil {
+		return nil, err
+	}
+
+	return res.Export(), nil
+}
+
+// deleteSpecMethod is a deleteSpec adapter method.
+func (self *goEnvironmentAdapter) deleteSpecMethod(specArg string, optsArg interface{}) (interface{}, error) {
+	fun, ok := goja.AssertFunction(self.adaptee.Get("deleteSpec"))
+	if !ok {
+		return nil, fmt.Errorf("%w: deleteSpec", errors.ErrUnsupported)
+	}
+
+	res, err := fun(self.adaptee)
+	if err != nil {
+		return nil, err
+	}
+
+	return res.Export(), nil
+}
+
+// deleteFileMethod is a deleteFile adapter method.
+func (self *goEnvironmentAdapter) deleteFileMethod(fileArg string, optsArg interface{}) (interface{}, error) {
+	fun, ok := goja.AssertFunction(self.adaptee.Get("deleteFile"))
+	if !ok {
+		return nil, fmt.Errorf("%w: deleteFile", errors.ErrUnsupported)
+	}
+
+	res, err := fun(self.adaptee)
+	if err != nil {
+		return nil, err
+	}
+
+	return res.Export(), nil
+}
+
+// patchMethod is a patch adapter method.
+func (self *goEnvironmentAdapter) patchMethod(resourceArg interface{}, patchArg interface{}, optsArg interface{}) (interface{}, error) {
+	fun, ok := goja.AssertFunction(self.adaptee.Get("patch"))
+	if !ok {
+		return nil, fmt.Errorf("%w: patch", errors.ErrUnsupported)
+	}
+
+	res, err := fun(self.adaptee)
+	if err != nil {
+		return nil, err
+	}
+
+	return res.Export(), nil
+}
+
+// scaleMethod is a scale adapter method.
+func (self *goEnvironmentAdapter) scaleMethod(kindArg string, nameArg string, namespaceArg string, replicasArg float64) (interface{}, error) {
+	fun, ok := goja.AssertFunction(self.adaptee.Get("scale"))
+	if !ok {
+		return nil, fmt.Errorf("%w: scale", errors.ErrUnsupported)
+	}
+
+	res, err := fun(self.adaptee)
+	if err != nil {
+		return nil, err
+	}
+
+	return res.Export(), nil
+}
+
+// rolloutRestartMethod is a rolloutRestart adapter method.
+func (self *goEnvironmentAdapter) rolloutRestartMethod(kindArg string, nameArg string, namespaceArg string) (interface{}, error) {
+	fun, ok := goja.AssertFunction(self.adaptee.Get("rolloutRestart"))
+	if !ok {
+		return nil, fmt.Errorf("%w: rolloutRestart", errors.ErrUnsupported)
+	}
+
+	res, err := fun(self.adaptee)
+	if err != nil {
+		return nil, err
+	}
+
+	return res.Export(), nil
+}
+
+// rolloutStatusMethod is a rolloutStatus adapter method.
+func (self *goEnvironmentAdapter) rolloutStatusMethod(kindArg string, nameArg string, namespaceArg string, optsArg interface{}) (interface{}, error) {
+	fun, ok := goja.AssertFunction(self.adaptee.Get("rolloutStatus"))
+	if !ok {
+		return nil, fmt.Errorf("%w: rolloutStatus", errors.ErrUnsupported)
+	}
+
+	res, err := fun(self.adaptee)
+	if err != nil {
+		return nil, err
+	}
+
+	return res.Export(), nil
+}
+
+// execMethod is a exec adapter method.
+func (self *goEnvironmentAdapter) execMethod(targetArg interface{}, commandArg interface{}, optsArg interface{}) (interface{}, error) {
+	fun, ok := goja.AssertFunction(self.adaptee.Get("exec"))
+	if !ok {
+		return nil, fmt.Errorf("%w: exec", errors.ErrUnsupported)
+	}
+
+	res, err := fun(self.adaptee)
+	if err != nil {
+		return nil, err
+	}
+
+	return res.Export(), nil
+}
+
+// logsMethod is a logs adapter method.
+func (self *goEnvironmentAdapter) logsMethod(targetArg interface{}) (interface{}, error) {
+	fun, ok := goja.AssertFunction(self.adaptee.Get("logs"))
+	if !ok {
+		return nil, fmt.Errorf("%w: logs", errors.ErrUnsupported)
+	}
+
+	res, err := fun(self.adaptee)
+	if err != nil {
+		return nil, err
+	}
+
+	return res.Export(), nil
+}
+
+// portForwardMethod is a portForward adapter method.
+func (self *goEnvironmentAdapter) portForwardMethod(targetArg interface{}) (interface{}, error) {
+	fun, ok := goja.AssertFunction(self.adaptee.Get("portForward"))
+	if !ok {
+		return nil, fmt.Errorf("%w: portForward", errors.ErrUnsupported)
+	}
+
+	res, err := fun(self.adaptee)
+	if err != nil {
+		return nil, err
+	}
+
+	return res.Export(), nil
+}
+
+// copyToMethod is a copyTo adapter method.
+func (self *goEnvironmentAdapter) copyToMethod(targetArg interface{}, localPathArg string, remotePathArg string, optsArg interface{}) (interface{}, error) {
+	fun, ok := goja.AssertFunction(self.adaptee.Get("copyTo"))
+	if !ok {
+		return nil, fmt.Errorf("%w: copyTo", errors.ErrUnsupported)
+	}
+
+	res, err := fun(self.adaptee)
+	if err != nil {
+		return nil, err
+	}
+
+	return res.Export(), nil
+}
+
+// copyFromMethod is a copyFrom adapter method.
+func (self *goEnvironmentAdapter) copyFromMethod(targetArg interface{}, remotePathArg string, localPathArg string, optsArg interface{}) (interface{}, error) {
+	fun, ok := goja.AssertFunction(self.adaptee.Get("copyFrom"))
+	if !ok {
+		return nil, fmt.Errorf("%w: copyFrom", errors.ErrUnsupported)
+	}
+
+	res, err := fun(self.adaptee)
+	if err != nil {
+		return nil, err
+	}
+
+	return res.Export(), nil
+}
+
 // jsEnvironmentTo setup Environment JavaScript object from jsEnvironment.
 func jsEnvironmentTo(src jsEnvironment, obj *goja.Object, vm *goja.Runtime) error {
 	if err := obj.Set("init", src.initMethod); err != nil {
@@ -391,7 +986,67 @@ func jsEnvironmentTo(src jsEnvironment, obj *goja.Object, vm *goja.Runtime) erro
 		return err
 	}
 
-	return obj.Set("getN", src.getNMethod)
+	if err := obj.Set("getN", src.getNMethod); err != nil {
+		return err
+	}
+
+	if err := obj.Set("setStatus", src.setStatusMethod); err != nil {
+		return err
+	}
+
+	if err := obj.Set("get", src.getMethod); err != nil {
+		return err
+	}
+
+	if err := obj.Set("list", src.listMethod); err != nil {
+		return err
+	}
+
+	if err := obj.Set("deleteResource", src.deleteResourceMethod); err != nil {
+		return err
+	}
+
+	if err := obj.Set("deleteSpec", src.deleteSpecMethod); err != nil {
+		return err
+	}
+
+	if err := obj.Set("deleteFile", src.deleteFileMethod); err != nil {
+		return err
+	}
+
+	if err := obj.Set("patch", src.patchMethod); err != nil {
+		return err
+	}
+
+	if err := obj.Set("scale", src.scaleMethod); err != nil {
+		return err
+	}
+
+	if err := obj.Set("rolloutRestart", src.rolloutRestartMethod); err != nil {
+		return err
+	}
+
+	if err := obj.Set("rolloutStatus", src.rolloutStatusMethod); err != nil {
+		return err
+	}
+
+	if err := obj.Set("exec", src.execMethod); err != nil {
+		return err
+	}
+
+	if err := obj.Set("logs", src.logsMethod); err != nil {
+		return err
+	}
+
+	if err := obj.Set("portForward", src.portForwardMethod); err != nil {
+		return err
+	}
+
+	if err := obj.Set("copyTo", src.copyToMethod); err != nil {
+		return err
+	}
+
+	return obj.Set("copyFrom", src.copyFromMethod)
 }
 
 // jsEnvironmentFrom returns a jsEnvironment based on a goEnvironment.
@@ -67,3 +67,78 @@ func (self *goEnvironmentImpl) waitMethod(conditionArg interface{}, optsArg inte
 func (self *goEnvironmentImpl) getNMethod(typeArg string, optsArg interface{}) (float64, error) {
 	return 0, errors.ErrUnsupported
 }
+
+// setStatusMethod is a goEnvironment method implementation.
+func (self *goEnvironmentImpl) setStatusMethod(resourceArg interface{}, statusArg interface{}) (interface{}, error) {
+	return nil, errors.ErrUnsupported
+}
+
+// getMethod is a goEnvironment method implementation.
+func (self *goEnvironmentImpl) getMethod(resourceArg interface{}) (interface{}, error) {
+	return nil, errors.ErrUnsupported
+}
+
+// listMethod is a goEnvironment method implementation.
+func (self *goEnvironmentImpl) listMethod(kindArg string, optsArg interface{}) (interface{}, error) {
+	return nil, errors.ErrUnsupported
+}
+
+// deleteResourceMethod is a goEnvironment method implementation.
+func (self *goEnvironmentImpl) deleteResourceMethod(resourceArg interface{}, optsArg interface{}) (interface{}, error) {
+	return nil, errors.ErrUnsupported
+}
+
+// deleteSpecMethod is a goEnvironment method implementation.
+func (self *goEnvironmentImpl) deleteSpecMethod(specArg string, optsArg interface{}) (interface{}, error) {
+	return nil, errors.ErrUnsupported
+}
+
+// deleteFileMethod is a goEnvironment method implementation.
+func (self *goEnvironmentImpl) deleteFileMethod(fileArg string, optsArg interface{}) (interface{}, error) {
+	return nil, errors.ErrUnsupported
+}
+
+// patchMethod is a goEnvironment method implementation.
+func (self *goEnvironmentImpl) patchMethod(resourceArg interface{}, patchArg interface{}, optsArg interface{}) (interface{}, error) {
+	return nil, errors.ErrUnsupported
+}
+
+// scaleMethod is a goEnvironment method implementation.
+func (self *goEnvironmentImpl) scaleMethod(kindArg string, nameArg string, namespaceArg string, replicasArg float64) (interface{}, error) {
+	return nil, errors.ErrUnsupported
+}
+
+// rolloutRestartMethod is a goEnvironment method implementation.
+func (self *goEnvironmentImpl) rolloutRestartMethod(kindArg string, nameArg string, namespaceArg string) (interface{}, error) {
+	return nil, errors.ErrUnsupported
+}
+
+// rolloutStatusMethod is a goEnvironment method implementation.
+func (self *goEnvironmentImpl) rolloutStatusMethod(kindArg string, nameArg string, namespaceArg string, optsArg interface{}) (interface{}, error) {
+	return nil, errors.ErrUnsupported
+}
+
+// execMethod is a goEnvironment method implementation.
+func (self *goEnvironmentImpl) execMethod(targetArg interface{}, commandArg interface{}, optsArg interface{}) (interface{}, error) {
+	return nil, errors.ErrUnsupported
+}
+
+// logsMethod is a goEnvironment method implementation.
+func (self *goEnvironmentImpl) logsMethod(targetArg interface{}) (interface{}, error) {
+	return nil, errors.ErrUnsupported
+}
+
+// portForwardMethod is a goEnvironment method implementation.
+func (self *goEnvironmentImpl) portForwardMethod(targetArg interface{}) (interface{}, error) {
+	return nil, errors.ErrUnsupported
+}
+
+// copyToMethod is a goEnvironment method implementation.
+func (self *goEnvironmentImpl) copyToMethod(targetArg interface{}, localPathArg string, remotePathArg string, optsArg interface{}) (interface{}, error) {
+	return nil, errors.ErrUnsupported
+}
+
+// copyFromMethod is a goEnvironment method implementation.
+func (self *goEnvironmentImpl) copyFromMethod(targetArg interface{}, remotePathArg string, localPathArg string, optsArg interface{}) (interface{}, error) {
+	return nil, errors.ErrUnsupported
+}
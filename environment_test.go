@@ -0,0 +1,427 @@
+package environment
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/dop251/goja"
+	"github.com/grafana/xk6-environment/pkg/environment"
+	"github.com/sirupsen/logrus"
+	logtest "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.k6.io/k6/js/modulestest"
+	"go.k6.io/k6/lib"
+	"go.k6.io/k6/metrics"
+)
+
+func newTestRuntime(t *testing.T) *goja.Runtime {
+	t.Helper()
+
+	rt := modulestest.NewRuntime(t)
+	mod := newModule(rt.VU)
+
+	vm := rt.VU.Runtime()
+	require.NoError(t, vm.Set("Environment", newEnvironmentConstructor(mod.newEnvironment)))
+	require.NoError(t, vm.Set("check", func(v goja.Value) {
+		if !goja.IsNull(v) && !goja.IsUndefined(v) {
+			t.Errorf("unexpected result: %s", v)
+		}
+	}))
+
+	return vm
+}
+
+func Test_FakeEnvironment(t *testing.T) {
+	t.Parallel()
+
+	vm := newTestRuntime(t)
+
+	_, err := vm.RunString(`
+const env = new Environment({
+  name: "test-fake-environment",
+  implementation: "fake",
+  logEvents: { types: ["Warning"] },
+})
+
+check(env.init())
+
+check(env.applySpec(` + "`" + `apiVersion: v1
+kind: Pod
+metadata:
+  name: nginx
+spec:
+  containers:
+  - name: nginx
+    image: nginx:1.14.2
+` + "`" + `))
+
+const n = env.getN("pods", { namespace: "default" })
+if (n !== 1) {
+  throw new Error("expected 1 pod, got " + n)
+}
+
+check(env.applySpec(` + "`" + `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: first
+  labels:
+    app: test
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: second
+  namespace: other
+  labels:
+    app: test
+` + "`" + `))
+
+if (env.getN("ConfigMap", { labels: { app: "test" } }) !== 1) {
+  throw new Error("expected 1 config map in default namespace")
+}
+
+const configMaps = env.list("ConfigMap", { labelSelector: "app=test", allNamespaces: true })
+if (!Array.isArray(configMaps) || configMaps.map((cm) => cm.metadata.name).sort().join() !== "first,second") {
+  throw new Error("unexpected config maps: " + JSON.stringify(configMaps))
+}
+
+check(env.setStatus({ kind: "Pod", name: "nginx", namespace: "default" }, { phase: "Running" }))
+
+const pod = env.get({ kind: "Pod", apiVersion: "v1", name: "nginx", namespace: "default" })
+if (pod.status.phase !== "Running" || pod.spec.containers[0].image !== "nginx:1.14.2") {
+  throw new Error("unexpected pod: " + JSON.stringify(pod))
+}
+
+check(env.wait({
+  kind: "Pod",
+  name: "nginx",
+  namespace: "default",
+  status_key: "phase",
+  status_value: "Running",
+}, {
+  interval: "10ms",
+  timeout: "1s",
+}))
+
+const patched = env.patch({ kind: "ConfigMap", name: "first" }, { data: { key: "value" } })
+if (patched.data.key !== "value" || patched.metadata.labels.app !== "test") {
+  throw new Error("unexpected patched config map: " + JSON.stringify(patched))
+}
+env.patch({ kind: "ConfigMap", name: "first" }, [{ op: "remove", path: "/data/key" }], { type: "json" })
+if ("key" in (env.get({ kind: "ConfigMap", name: "first" }).data || {})) {
+  throw new Error("expected data of config map to be removed")
+}
+
+check(env.applySpec(` + "`" + `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app
+spec:
+  replicas: 1
+` + "`" + `))
+check(env.scale("Deployment", "app", "default", 2))
+check(env.rolloutRestart("Deployment", "app", "default"))
+// there are no controllers in the fake environment
+env.patch({ kind: "Deployment", name: "app" }, { status: { replicas: 2, updatedReplicas: 2, availableReplicas: 2 } },
+  { subresource: "status" })
+check(env.rolloutStatus("Deployment", "app", "default", { interval: "10ms", timeout: "1s" }))
+
+if (env.logs({ pod: "nginx", namespace: "default", tailLines: 10 }) !== "fake logs") {
+  throw new Error("unexpected logs of pod")
+}
+const logs = env.logs({ labelSelector: "app=test", namespace: "default", previous: true })
+if (JSON.stringify(logs) !== "{}") {
+  throw new Error("unexpected logs of selected pods: " + JSON.stringify(logs))
+}
+
+check(env.deleteResource({ kind: "ConfigMap", labels: { app: "test" }, allNamespaces: true }, { wait: true, interval: "10ms" }))
+check(env.deleteResource({ kind: "Pod", name: "nginx", namespace: "default" }, { propagationPolicy: "Foreground" }))
+if (env.list("ConfigMap", { allNamespaces: true }).length !== 0 || env.getN("pods") !== 0) {
+  throw new Error("expected all objects to be deleted")
+}
+
+check(env.delete())
+`)
+	require.NoError(t, err)
+}
+
+func Test_EnvironmentErrors(t *testing.T) {
+	t.Parallel()
+
+	vm := newTestRuntime(t)
+
+	_, err := vm.RunString(`
+function expectThrow(name, f) {
+  try {
+    f()
+  } catch (e) {
+    if (e.name !== name) {
+      throw new Error("expected " + name + ", got " + e.name + ": " + e.message)
+    }
+    return
+  }
+  throw new Error("expected " + name + ", got no exception")
+}
+
+expectThrow("ValidationError", () => new Environment({ name: "test-errors", implementation: "unknown" }))
+expectThrow("ValidationError", () => new Environment({ name: "test-errors", implementation: " " }))
+expectThrow("ValidationError", () => new Environment({ name: "test-errors", implementation: "fake", logEvents: { types: "Warning" } }))
+
+const env = new Environment({
+  name: "test-errors",
+  implementation: "fake",
+  throwErrors: true,
+})
+
+check(env.init())
+
+expectThrow("ValidationError", () => env.setStatus({ kind: "Pod" }, {}))
+expectThrow("ValidationError", () => env.wait({ kind: "Pod" }))
+expectThrow("NotFoundError", () => env.setStatus({ kind: "Pod", name: "missing", namespace: "default" }, {}))
+expectThrow("NotFoundError", () => env.setStatus({ kind: "Unknown", name: "missing", namespace: "default" }, {}))
+expectThrow("NotFoundError", () => env.get({ kind: "Pod", name: "missing" }))
+expectThrow("NotFoundError", () => env.get({ kind: "Pod", apiVersion: "example.com/v1", name: "missing" }))
+expectThrow("NotFoundError", () => env.deleteResource({ kind: "Pod", name: "missing" }))
+expectThrow("ValidationError", () => env.list("Pod", { app: "nginx" }))
+expectThrow("ValidationError", () => env.deleteResource({ kind: "Pod" }))
+expectThrow("ValidationError", () => env.deleteResource({ kind: "Pod", app: "nginx" }))
+expectThrow("ValidationError", () => env.deleteResource({ kind: "Pod", labels: { app: "nginx" }, tier: "web" }))
+expectThrow("ValidationError", () => env.deleteResource({ kind: "Pod", name: "nginx" }, { force: true }))
+expectThrow("ValidationError", () => env.patch({ kind: "Pod", name: "missing" }, {}, { type: "unknown" }))
+expectThrow("NotFoundError", () => env.patch({ kind: "Pod", name: "missing" }, {}))
+expectThrow("ValidationError", () => env.scale("Deployment", "missing", "default", 1.5))
+expectThrow("ValidationError", () => env.rolloutRestart("Pod", "missing", "default"))
+expectThrow("NotFoundError", () => env.rolloutStatus("Deployment", "missing", "default"))
+expectThrow("ValidationError", () => env.exec({ namespace: "default" }, ["ls"]))
+expectThrow("ValidationError", () => env.exec({ pod: "nginx" }, []))
+expectThrow("ValidationError", () => env.exec({ pod: "nginx" }, ["sleep", 1]))
+expectThrow("ValidationError", () => env.exec({ pod: "nginx" }, ["ls"], { timeout: "never" }))
+expectThrow("ValidationError", () => env.logs({ namespace: "default" }))
+expectThrow("ValidationError", () => env.logs({ pod: "nginx", labelSelector: "app=nginx" }))
+expectThrow("ValidationError", () => env.logs({ pod: "nginx", tailLines: "10" }))
+expectThrow("ValidationError", () => env.portForward({ namespace: "default", port: 80 }))
+expectThrow("ValidationError", () => env.portForward({ service: "nginx", pod: "nginx", port: 80 }))
+expectThrow("NotFoundError", () => env.portForward({ service: "missing", port: 80 }))
+expectThrow("ValidationError", () => env.copyTo({ namespace: "default" }, "local", "/tmp/remote"))
+expectThrow("ValidationError", () => env.copyTo({ pod: "nginx" }, "local", "/"))
+expectThrow("ValidationError", () => env.copyFrom({ pod: "nginx" }, "/tmp/remote", "local", { maxBytes: "1M" }))
+expectThrow("ValidationError", () => env.deleteSpec("", { propagationPolicy: "Never" }))
+check(env.deleteResource({ kind: "Pod", name: "missing" }, { ignoreNotFound: true }))
+expectThrow("TimeoutError", () => env.wait({
+  kind: "Pod",
+  name: "missing",
+  namespace: "default",
+  status_key: "phase",
+  status_value: "Running",
+}, {
+  interval: "10ms",
+  timeout: "50ms",
+}))
+
+check(env.delete())
+
+// errors are returned as strings by default
+const legacy = new Environment({
+  name: "test-errors-legacy",
+  implementation: "fake",
+})
+
+check(legacy.init())
+
+if (typeof legacy.setStatus({ kind: "Pod" }, {}) !== "string") {
+  throw new Error("expected error as string")
+}
+
+if (legacy.getN("deployments") !== 0) {
+  throw new Error("expected 0 on error")
+}
+
+check(legacy.delete())
+`)
+	require.NoError(t, err)
+}
+
+func Test_EnvironmentLogsErrors(t *testing.T) {
+	t.Parallel()
+
+	rt := modulestest.NewRuntime(t)
+	logger, hook := logtest.NewNullLogger()
+	rt.VU.InitEnvField.Logger = logger
+	mod := newModule(rt.VU)
+
+	vm := rt.VU.Runtime()
+	require.NoError(t, vm.Set("Environment", newEnvironmentConstructor(mod.newEnvironment)))
+
+	_, err := vm.RunString(`
+const env = new Environment({
+  name: "test-logs-errors",
+  implementation: "fake",
+  throwErrors: false,
+})
+
+env.init()
+if (env.getN("unknown") !== 0) {
+  throw new Error("expected 0 on error")
+}
+env.delete()
+`)
+	require.NoError(t, err)
+
+	// errors of getN() can't be returned as strings, so they are logged
+	logged := false
+	for _, entry := range hook.AllEntries() {
+		if entry.Message == "getN() failed" {
+			logged = true
+			assert.Equal(t, logrus.WarnLevel, entry.Level)
+			assert.Error(t, entry.Data[logrus.ErrorKey].(error))
+		}
+	}
+	assert.True(t, logged)
+}
+
+func Test_selectedLogs(t *testing.T) {
+	t.Parallel()
+
+	rt := modulestest.NewRuntime(t)
+	logger, hook := logtest.NewNullLogger()
+	rt.VU.InitEnvField.Logger = logger
+	impl := goEnvironmentImpl{vu: rt.VU}
+
+	errLogs := errors.New("unable to get logs of pod nginx-1")
+
+	// logs of the other pods are returned
+	logs := map[string]string{"nginx-0": "started"}
+	assert.Equal(t, logs, impl.selectedLogs(logs, errLogs))
+	require.Len(t, hook.AllEntries(), 1)
+	assert.Equal(t, logrus.WarnLevel, hook.LastEntry().Level)
+	assert.Equal(t, errLogs, hook.LastEntry().Data[logrus.ErrorKey])
+
+	// none of the pods
+	assert.Equal(t, errLogs.Error(), impl.selectedLogs(map[string]string{}, errLogs))
+
+	assert.Equal(t, logs, impl.selectedLogs(logs, nil))
+}
+
+func Test_EnvironmentMetrics(t *testing.T) {
+	t.Parallel()
+
+	rt := modulestest.NewRuntime(t)
+	mod := newModule(rt.VU)
+
+	vm := rt.VU.Runtime()
+	require.NoError(t, vm.Set("Environment", newEnvironmentConstructor(mod.newEnvironment)))
+
+	// metrics are registered in the init context
+	_, err := vm.RunString(`
+const env = new Environment({
+  name: "test-metrics",
+  implementation: "fake",
+  throwErrors: false,
+})
+`)
+	require.NoError(t, err)
+
+	registry := rt.VU.InitEnv().Registry
+	samples := make(chan metrics.SampleContainer, 100)
+	rt.MoveToVUContext(&lib.State{
+		Samples: samples,
+		Tags:    lib.NewVUStateTags(registry.RootTagSet().With("scenario", "default")),
+	})
+
+	// samples are pushed by VUs
+	_, err = vm.RunString(`
+env.init()
+env.applySpec(` + "`" + `apiVersion: v1
+kind: Pod
+metadata:
+  name: nginx
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: config
+` + "`" + `)
+env.wait({
+  kind: "Pod",
+  name: "missing",
+  namespace: "default",
+  status_key: "phase",
+  status_value: "Running",
+}, {
+  interval: "10ms",
+  timeout: "50ms",
+})
+env.delete()
+`)
+	require.NoError(t, err)
+	close(samples)
+
+	durations := map[string]map[string]string{}
+	operations := 0
+	for container := range samples {
+		for _, sample := range container.GetSamples() {
+			tags := sample.Tags.Map()
+			assert.Equal(t, "test-metrics", tags["environment"])
+			assert.Equal(t, "default", tags["scenario"])
+
+			switch sample.Metric.Name {
+			case operationsName:
+				operations++
+				assert.Equal(t, 1.0, sample.Value)
+			default:
+				durations[sample.Metric.Name] = tags
+				assert.Equal(t, metrics.Trend, sample.Metric.Type)
+			}
+		}
+	}
+
+	assert.Equal(t, 4, operations)
+	require.Len(t, durations, 4)
+	assert.Equal(t, map[string]string{
+		"scenario":    "default",
+		"environment": "test-metrics",
+		"operation":   "wait",
+		"kind":        "Pod",
+		"condition":   "phase",
+		"outcome":     "TimeoutError",
+	}, durations[waitDurationName])
+	// kinds of applied objects, sorted
+	assert.Equal(t, "ConfigMap,Pod", durations[applyDurationName]["kind"])
+	assert.Equal(t, "applySpec", durations[applyDurationName]["operation"])
+	assert.Equal(t, "init", durations[initDurationName]["operation"])
+	assert.Equal(t, "success", durations[initDurationName]["outcome"])
+	assert.Equal(t, "success", durations[deleteDurationName]["outcome"])
+}
+
+func Test_recordWithoutDuration(t *testing.T) {
+	t.Parallel()
+
+	rt := modulestest.NewRuntime(t)
+	registry := rt.VU.InitEnv().Registry
+	operations, err := registry.NewMetric(operationsName, metrics.Counter)
+	require.NoError(t, err)
+
+	samples := make(chan metrics.SampleContainer, 10)
+	rt.MoveToVUContext(&lib.State{
+		Samples: samples,
+		Tags:    lib.NewVUStateTags(registry.RootTagSet()),
+	})
+
+	impl := goEnvironmentImpl{
+		e:       &environment.Environment{TestName: "test"},
+		vu:      rt.VU,
+		metrics: &envMetrics{durations: map[string]*metrics.Metric{}, operations: operations},
+	}
+	impl.record(impl.measure("unknown"), nil)
+	close(samples)
+
+	// the operation is counted only
+	var names []string
+	for container := range samples {
+		for _, sample := range container.GetSamples() {
+			names = append(names, sample.Metric.Name)
+		}
+	}
+	assert.Equal(t, []string{operationsName}, names)
+}
@@ -0,0 +1,52 @@
+package environment
+
+import (
+	"errors"
+
+	"github.com/dop251/goja"
+	"github.com/grafana/xk6-environment/pkg/environment"
+	"github.com/grafana/xk6-environment/pkg/kubernetes"
+)
+
+// Names of JS exceptions thrown by Environment, so that scripts
+// can catch errors selectively:
+//
+//	try {
+//	  env.wait(condition, { timeout: "1m" })
+//	} catch (e) {
+//	  if (e.name !== "TimeoutError") throw e
+//	}
+const (
+	providerErrorName   = "ProviderError"
+	validationErrorName = "ValidationError"
+	timeoutErrorName    = "TimeoutError"
+	notFoundErrorName   = "NotFoundError"
+	genericErrorName    = "Error"
+)
+
+// errorName classifies err by the sentinel errors it wraps. Failure of
+// the environment itself takes precedence over the cause of that failure.
+func errorName(err error) string {
+	switch {
+	case errors.Is(err, environment.ErrProvider):
+		return providerErrorName
+	case errors.Is(err, environment.ErrValidation), errors.Is(err, kubernetes.ErrInvalid):
+		return validationErrorName
+	case errors.Is(err, kubernetes.ErrTimeout):
+		return timeoutErrorName
+	case kubernetes.IsNotFound(err):
+		return notFoundErrorName
+	default:
+		return genericErrorName
+	}
+}
+
+// throw throws err as a JS exception, named after its class.
+func throw(rt *goja.Runtime, err error) {
+	obj := rt.NewGoError(err)
+	if err := obj.Set("name", errorName(err)); err != nil {
+		panic(err)
+	}
+
+	panic(obj)
+}
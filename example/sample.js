@@ -5,11 +5,12 @@ const env = new Environment({
   name: "some-name", // name of the test = name of vcluster
   implementation: "vcluster", // k8s is prerequisite
   initFolder: "example/", // initial folder with everything that wil be loaded at init
+  vcluster: { chart: "vcluster-0.20.0.tgz" }, // local vcluster chart, see https://github.com/loft-sh/vcluster/releases
 })
 
 export function setup() {
   // to ensure execution happens only once, we run creation of environment in setup
-  console.log("init returns", env.init());
+  env.init();
 }
 
 export default function () {
@@ -29,27 +30,33 @@ spec:
 
   // sync function, blocking
   // Wait until nginx Pod generates Kubernetes event "Started"
-  let err = env.wait({
+  env.wait({
     kind: "Pod",
     name: "nginx",
     namespace: "default",
     reason: "Started", // k8s event
   });
-  console.log("wait for nginx returns", err);
 
   // Wait until .status.conditions Ready reaches value True
-  // for nginx2 Pod
-  console.log("wait for nginx2 returns", env.wait({
-    kind: "Pod",
-    name: "nginx2",
-    namespace: "default",
-    condition_type: "Ready",
-    value: "True",
-  }, {
-    timeout: "1m",
-  }));
+  // for nginx2 Pod; errors are thrown, so they can be caught selectively
+  try {
+    env.wait({
+      kind: "Pod",
+      name: "nginx2",
+      namespace: "default",
+      condition_type: "Ready",
+      value: "True",
+    }, {
+      timeout: "1m",
+    });
+  } catch (e) {
+    if (e.name !== "TimeoutError") {
+      throw e;
+    }
+    console.log("nginx2 is not ready in time:", e.message);
+  }
 }
 
 export function teardown() {
-  console.log("delete returns", env.delete());
+  env.delete();
 }
\ No newline at end of file
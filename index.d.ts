@@ -35,6 +35,7 @@ export as namespace environment;
  *     name: "my-env",
  *     implementation: "vcluster",
  *     initFolder: "my-folder-with-manifests/",
+ *     vcluster: { chart: "vcluster-0.20.0.tgz" },
  *   })
  * }
  * ```
@@ -44,8 +45,15 @@ export declare class Environment {
    * Defines a new Environment instance.
    *
    * @param name name of the environment
-   * @param implementation implementation for the environment (only "vcluster" for now)
+   * @param implementation implementation for the environment, one of "vcluster" (default), "namespace", "envtest", "fake", "kwok" or "existing"; an unknown or empty implementation results in an error
    * @param initFolder optional, a folder containing base manifests to apply on initialization of environment
+   * @param vcluster optional, configuration of "vcluster" implementation, like `{chart: "vcluster-0.20.0.tgz"}`: vcluster is installed with Helm from a local `chart` or, only if given, from `chartRepository` with an optional `chartVersion`, `valuesFile` and `values` are chart values, `kubernetesVersion` and `distro` select Kubernetes within vcluster, deprecated `cli: true` creates vcluster with vcluster CLI instead
+   * @param envtest optional, configuration of "envtest" implementation, like `{binaryAssetsDirectory: "dir"}`
+   * @param kwok optional, configuration of simulated nodes of "kwok" implementation, like `{nodes: 100, cpu: "32", memory: "256Gi", pods: 110}`
+   * @param context optional, Kubernetes context of the cluster for "existing" implementation; the current context is used by default
+   * @param kubeconfig optional, path to Kubeconfig of the host cluster; by default, KUBECONFIG environment variable is used, which can be a list of files to merge, or ~/.kube/config
+   * @param throwErrors optional, whether methods throw errors as exceptions named "ProviderError", "ValidationError", "TimeoutError", "NotFoundError" or "Error"; false by default, so that errors are returned as strings
+   * @param logEvents optional, Kubernetes events to log from `init()` until `delete()`, like `{types: ["Warning"], namespaces: ["default"]}`: events of all types and of all namespaces of the environment are logged by default; each event is logged with its namespace, kind, name, type, reason and count as fields, at warning level for "Warning" events
    */
   constructor(params: object);
 
@@ -62,14 +70,14 @@ export declare class Environment {
   // apply(files: string[]); arrays are not supported by Tygor yet
 
   /**
-   * apply reads the contents of the file and applies them to the virtual cluster.
-   * @param file is expected to be a readable yaml file (Kubernetes manifest).
+   * apply reads the contents of the file and applies them to the virtual cluster. All documents are applied even if some of them fail; errors refer to the failed documents by their index and object identity.
+   * @param file is expected to be a readable yaml file (Kubernetes manifest); it may contain multiple documents separated by `---` and objects of kind List.
    */
   apply(file: string);
 
   /**
    * applySpec applies the spec to the virtual cluster.
-   * @param spec is expected to be a yaml manifest.
+   * @param spec is expected to be a yaml manifest; it may contain multiple documents separated by `---` and objects of kind List.
    */
   applySpec(spec: string); // we have to use a diff name here: method overload is not supported
 
@@ -93,14 +101,143 @@ export declare class Environment {
 
   /**
    * getN is a substitute for get(), hopefully temporary. See [tygor's](https://github.com/szkiba/tygor) roadmap about support for arrays.
-   * @param type is a kind of resource (currently only "pods" are supported).
-   * @param opts optional parameteters for the resource, like namespace and labels.
+   * @param type is a kind of resource, like "Pod", or the name of its resource, like "pods".
+   * @param opts optional parameteters for the resource, the same as in list().
    */
   getN(type: string, opts?: object): number;
 
-  // TODO:
-  // list(resource: string, namespace: string);
-  // delete();
+  /**
+   * setStatus replaces `.status` of the object within the environment. Normally `.status`
+   * is set by Kubernetes controllers, so this is meant to simulate them, e.g. with the "fake"
+   * implementation.
+   * @param resource describes the object with kind, name and namespace fields.
+   * @param status is the new value of `.status`.
+   */
+  setStatus(resource: object, status: object);
+
+  /**
+   * get returns the object within environment, as it is represented in JSON, so that
+   * its spec and status can be checked directly. The object is looked up by kind, name
+   * and namespace, with optional apiVersion: by default, the preferred version of kind is used.
+   * @param resource describes the object with kind, name, namespace and optional apiVersion fields, like `{kind: "Deployment", apiVersion: "apps/v1", name: "app", namespace: "default"}`.
+   */
+  get(resource: object): any;
+
+  /**
+   * list returns objects of the given kind within environment, as they are represented in JSON.
+   * @param kind is a kind of objects, like "Deployment" or a kind of CRD, or the name of its resource, like "deployments".
+   * @param opts optional `{namespace, apiVersion, labelSelector, labels, fieldSelector, allNamespaces, limit}`, like `{namespace: "default", labelSelector: "app=nginx"}`. Namespace is "default" by default; with `allNamespaces: true`, objects in all namespaces of environment are listed. Labels to select by can also be given as an object, like `{labels: {app: "nginx"}}`; any other key is an error.
+   */
+  list(kind: string, opts?: object): any[];
+
+  /**
+   * deleteResource removes the object within environment, given by kind, name and namespace, or all
+   * objects of the kind selected by labelSelector or labels when name is omitted. Options set propagation
+   * policy of dependents and whether to wait until the objects are gone.
+   * @param resource describes the object with kind, name, namespace and optional apiVersion fields, like `{kind: "Deployment", name: "app", namespace: "default"}`; without name, objects are selected as in list(), like `{kind: "Pod", labelSelector: "app=nginx"}` or `{kind: "Pod", labels: {app: "nginx"}}`, and either labelSelector or labels is required.
+   * @param opts optional `{propagationPolicy, ignoreNotFound, wait, interval, timeout}`, like `{propagationPolicy: "Foreground", wait: true, timeout: "2m"}`. Propagation policy is one of "Background", "Foreground" or "Orphan"; with `ignoreNotFound: true`, missing objects are not an error; with `wait: true`, deletion blocks until the objects are gone (defaults of interval and timeout are the same as in wait()); any other key is an error.
+   */
+  deleteResource(resource: object, opts?: object);
+
+  /**
+   * deleteSpec removes the objects described by the manifest spec, e.g. those deployed with applySpec.
+   * @param spec is a string with Kubernetes manifests; multiple documents and List are supported as in applySpec().
+   * @param opts optional options of deletion, the same as in deleteResource().
+   */
+  deleteSpec(spec: string, opts?: object);
+
+  /**
+   * deleteFile removes the objects described by the manifest file, e.g. those deployed with apply.
+   * @param file is a path to the file with Kubernetes manifests.
+   * @param opts optional options of deletion, the same as in deleteResource().
+   */
+  deleteFile(file: string, opts?: object);
+
+  /**
+   * patch changes the object within environment with a merge, JSON, strategic merge or apply patch
+   * and returns the result. Unlike applySpec, only the patched fields are owned by xk6-environment.
+   * The status and scale subresources can be patched as well.
+   * @param resource describes the object with kind, name, namespace and optional apiVersion fields, like `{kind: "Deployment", name: "app", namespace: "default"}`.
+   * @param patch is the patch, as an object or array, like `{spec: {replicas: 3}}`, or as a JSON string.
+   * @param opts optional `{type, subresource}`. Type is one of "merge" (default), "json", "strategic" or "apply"; subresource is either "status" or "scale", like `{type: "merge", subresource: "status"}`. The result of patching the scale subresource is a Scale object.
+   */
+  patch(resource: object, patch: any, opts?: object): any;
+
+  /**
+   * scale sets the number of replicas of Deployment, StatefulSet, ReplicaSet or any other kind
+   * with the scale subresource.
+   * @param kind is a kind of the object, like "Deployment".
+   * @param name is a name of the object.
+   * @param namespace is a namespace of the object.
+   * @param replicas is the desired number of replicas.
+   */
+  scale(kind: string, name: string, namespace: string, replicas: number);
+
+  /**
+   * rolloutRestart restarts rollout of Deployment or StatefulSet, like `kubectl rollout restart`.
+   * @param kind is a kind of the object, either "Deployment" or "StatefulSet".
+   * @param name is a name of the object.
+   * @param namespace is a namespace of the object.
+   */
+  rolloutRestart(kind: string, name: string, namespace: string);
+
+  /**
+   * rolloutStatus blocks until rollout of Deployment, StatefulSet or ReplicaSet is complete, like
+   * `kubectl rollout status`: the latest spec is observed and all replicas are updated and available.
+   * Pods of StatefulSet are expected to be updated only up to the rolling update partition,
+   * and StatefulSet with `OnDelete` update strategy is not supported.
+   * @param kind is a kind of the object, like "Deployment".
+   * @param name is a name of the object.
+   * @param namespace is a namespace of the object.
+   * @param opts optional configuration of timeout and interval, the same as in wait().
+   */
+  rolloutStatus(kind: string, name: string, namespace: string, opts?: object);
+
+  /**
+   * exec runs the command in a container of the pod, like `kubectl exec`, and returns its output
+   * and exit code as `{stdout, stderr, exitCode}`. Non-zero exit code of the command is not an error.
+   * @param target describes the container with pod, namespace and optional container fields, like `{pod: "nginx", namespace: "default", container: "nginx"}`; container can be omitted if the pod has only one.
+   * @param command is the command with its arguments, like `["curl", "-s", "http://app"]`; it's not run in a shell.
+   * @param opts optional `{stdin, timeout}`, like `{stdin: "input", timeout: "30s"}`.
+   */
+  exec(target: object, command: string[], opts?: object): any;
+
+  /**
+   * logs returns logs of a container of the pod, like `kubectl logs`, or a map of pod name to logs
+   * when pods are selected by labelSelector.
+   * Pods whose logs cannot be fetched are skipped and their errors are logged as warnings;
+   * it fails only if logs of none of the selected pods can be fetched.
+   * @param target `{pod, labelSelector, namespace, container, tailLines, sinceSeconds, previous}`, like `{pod: "nginx", namespace: "default", tailLines: 100}` or `{labelSelector: "app=nginx", sinceSeconds: 60}`. Either pod or labelSelector is required; container can be omitted if pods have only one; with `previous: true`, logs of the previous instance of the container are returned, e.g. of the one which crashed.
+   */
+  logs(target: object): any;
+
+  /**
+   * portForward opens a tunnel to the port of the service or pod, like `kubectl port-forward`, and returns
+   * its local URL. Connections to a service are forwarded to a ready pod of it, which is chosen again
+   * when the pod is gone or not ready. Tunnels are shared by VUs and stay open until the environment is deleted.
+   * @param target `{service, pod, namespace, port}`, like `{service: "nginx", namespace: "default", port: 80}` or `{pod: "nginx-0", port: 8080}`. Either service or pod is required; port of the service can be omitted if it has only one.
+   */
+  portForward(target: object): string;
+
+  /**
+   * copyTo copies the local file or directory into a container of the pod, like `kubectl cp`,
+   * with tar over exec. Modes of files are preserved.
+   * @param target describes the container with pod, namespace and optional container fields, like in exec(). The container must have tar binary.
+   * @param localPath is a path of the local file or directory.
+   * @param remotePath is a path of the copy in the container, like "/tmp/fixtures"; its directory must exist.
+   * @param opts optional `{maxBytes, timeout}`, like `{maxBytes: 1048576, timeout: "1m"}`; with maxBytes, copying fails if the total size of files is larger.
+   */
+  copyTo(target: object, localPath: string, remotePath: string, opts?: object);
+
+  /**
+   * copyFrom copies the file or directory from a container of the pod to the local path, like
+   * `kubectl cp`, with tar over exec. Modes of files are preserved; symbolic links are skipped.
+   * @param target describes the container with pod, namespace and optional container fields, like in exec(). The container must have tar binary.
+   * @param remotePath is a path of the file or directory in the container, like "/tmp/profile.out".
+   * @param localPath is a path of the local copy.
+   * @param opts optional `{maxBytes, timeout}`, like in copyTo().
+   */
+  copyFrom(target: object, remotePath: string, localPath: string, opts?: object);
 }
 
 /** Default Environment instance. */
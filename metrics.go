@@ -0,0 +1,155 @@
+package environment
+
+import (
+	"strings"
+	"time"
+
+	"github.com/grafana/xk6-environment/pkg/kubernetes"
+
+	"go.k6.io/k6/js/modules"
+	"go.k6.io/k6/metrics"
+)
+
+// Names of custom k6 metrics of environment, so that thresholds can be
+// defined for them, e.g. "environment_wait_duration{condition:Ready}".
+const (
+	initDurationName   = "environment_init_duration"
+	applyDurationName  = "environment_apply_duration"
+	waitDurationName   = "environment_wait_duration"
+	deleteDurationName = "environment_delete_duration"
+	operationsName     = "environment_operations"
+)
+
+// Outcome of a successful operation; failed operations have the name of
+// their exception as outcome, like "TimeoutError".
+const successOutcome = "success"
+
+// envMetrics are custom k6 metrics of lifecycle operations of environment.
+type envMetrics struct {
+	// durations are Trend metrics, by operation
+	durations  map[string]*metrics.Metric
+	operations *metrics.Metric
+}
+
+// registerMetrics registers the metrics in the registry of k6; metrics
+// can be registered only in the init context, otherwise nil is returned.
+// The same metrics are returned to all VUs.
+func registerMetrics(vu modules.VU) (*envMetrics, error) {
+	initEnv := vu.InitEnv()
+	if initEnv == nil || initEnv.Registry == nil {
+		return nil, nil //nolint:nilnil
+	}
+
+	trends := make(map[string]*metrics.Metric)
+	for _, name := range []string{initDurationName, applyDurationName, waitDurationName, deleteDurationName} {
+		metric, err := initEnv.Registry.NewMetric(name, metrics.Trend, metrics.Time)
+		if err != nil {
+			return nil, err
+		}
+		trends[name] = metric
+	}
+
+	operations, err := initEnv.Registry.NewMetric(operationsName, metrics.Counter)
+	if err != nil {
+		return nil, err
+	}
+
+	return &envMetrics{
+		durations: map[string]*metrics.Metric{
+			"init":          trends[initDurationName],
+			"apply":         trends[applyDurationName],
+			"applySpec":     trends[applyDurationName],
+			"wait":          trends[waitDurationName],
+			"rolloutStatus": trends[waitDurationName],
+			"delete":        trends[deleteDurationName],
+		},
+		operations: operations,
+	}, nil
+}
+
+// measurement of an operation of environment.
+type measurement struct {
+	operation string
+	start     time.Time
+	// tags of the operation, like kind of object
+	tags map[string]string
+}
+
+// measure starts measurement of the operation.
+func (impl goEnvironmentImpl) measure(operation string) *measurement {
+	if impl.metrics == nil {
+		return nil
+	}
+
+	return &measurement{
+		operation: operation,
+		start:     time.Now(),
+		tags:      map[string]string{},
+	}
+}
+
+// measureWait starts measurement of waiting for the condition.
+func (impl goEnvironmentImpl) measureWait(operation string, wc *kubernetes.WaitCondition) *measurement {
+	m := impl.measure(operation)
+	if m != nil {
+		m.tags["kind"] = wc.Kind
+		m.tags["condition"] = wc.Condition()
+	}
+
+	return m
+}
+
+// tagKinds tags measurement of applying the manifest spec with kinds of its
+// objects, sorted and joined with commas, like "ConfigMap,Deployment".
+func (impl goEnvironmentImpl) tagKinds(m *measurement, spec string) {
+	if m == nil {
+		return
+	}
+
+	if kinds := kubernetes.Kinds(spec); len(kinds) > 0 {
+		m.tags["kind"] = strings.Join(kinds, ",")
+	}
+}
+
+// record pushes samples of the measured operation with its outcome. Samples
+// can be pushed only by a VU, so operations of the init context aren't
+// recorded.
+func (impl goEnvironmentImpl) record(m *measurement, err error) {
+	if m == nil {
+		return
+	}
+
+	state := impl.vu.State()
+	if state == nil {
+		return
+	}
+
+	outcome := successOutcome
+	if err != nil {
+		outcome = errorName(err)
+	}
+
+	tags := state.Tags.GetCurrentValues().Tags.WithTagsFromMap(m.tags).
+		With("operation", m.operation).
+		With("environment", impl.e.TestName).
+		With("outcome", outcome)
+	now := time.Now()
+
+	samples := metrics.Samples{
+		{
+			TimeSeries: metrics.TimeSeries{Metric: impl.metrics.operations, Tags: tags},
+			Time:       now,
+			Value:      1,
+		},
+	}
+	// operations without duration metric are only counted
+	if duration := impl.metrics.durations[m.operation]; duration != nil {
+		samples = append(samples, metrics.Sample{
+			TimeSeries: metrics.TimeSeries{Metric: duration, Tags: tags},
+			Time:       now,
+			Value:      metrics.D(now.Sub(m.start)),
+		})
+	}
+
+	metrics.PushIfNotDone(impl.vu.Context(), state.Samples, samples)
+}
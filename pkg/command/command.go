@@ -0,0 +1,120 @@
+// Package command runs CLI tools which implementations of environment
+// depend on, reporting their failures with exit code and output.
+package command
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+)
+
+// outputTailLines is how many last lines of command output are kept in Error.
+const outputTailLines = 20
+
+// Error is returned when a command fails.
+type Error struct {
+	// Command is the command line that was executed.
+	Command string
+	// ExitCode is the exit code of the command, or -1 if the command
+	// could not be started or was terminated by a signal.
+	ExitCode int
+	// Output is the tail of stdout and stderr of the command.
+	Output string
+
+	err error
+}
+
+func (e *Error) Error() string {
+	msg := fmt.Sprintf("%q failed with exit code %d: %v", e.Command, e.ExitCode, e.err)
+	if len(e.Output) > 0 {
+		msg += "\n" + e.Output
+	}
+
+	return msg
+}
+
+func (e *Error) Unwrap() error {
+	return e.err
+}
+
+func newError(cmd *exec.Cmd, err error, output []string) *Error {
+	exitCode := -1
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		exitCode = exitErr.ExitCode()
+	}
+
+	if len(output) > outputTailLines {
+		output = output[len(output)-outputTailLines:]
+	}
+
+	return &Error{
+		Command:  strings.Join(cmd.Args, " "),
+		ExitCode: exitCode,
+		Output:   strings.Join(output, "\n"),
+		err:      err,
+	}
+}
+
+// Run executes the command and, if logger is not nil, streams its output
+// to the logger line by line. If the command fails, Error is returned.
+func Run(cmd *exec.Cmd, logger logrus.FieldLogger) error {
+	pr, pw := io.Pipe()
+	cmd.Stdout = pw
+	cmd.Stderr = pw
+
+	tail := make([]string, 0, outputTailLines)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+
+		scanner := bufio.NewScanner(pr)
+		for scanner.Scan() {
+			line := scanner.Text()
+			if len(tail) == outputTailLines {
+				tail = tail[1:]
+			}
+			tail = append(tail, line)
+
+			if logger != nil {
+				logger.Info(line)
+			}
+		}
+		// drain whatever is left, e.g. after a too long line
+		_, _ = io.Copy(io.Discard, pr)
+	}()
+
+	err := cmd.Start()
+	if err == nil {
+		err = cmd.Wait()
+	}
+	_ = pw.Close()
+	<-done
+
+	if err != nil {
+		return newError(cmd, err, tail)
+	}
+
+	return nil
+}
+
+// Output executes the command and returns its stdout. If the command fails,
+// Error is returned with the tail of its stderr.
+func Output(cmd *exec.Cmd) ([]byte, error) {
+	out, err := cmd.Output()
+	if err != nil {
+		var output []string
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && len(exitErr.Stderr) > 0 {
+			output = strings.Split(strings.TrimSpace(string(exitErr.Stderr)), "\n")
+		}
+		return nil, newError(cmd, err, output)
+	}
+
+	return out, nil
+}
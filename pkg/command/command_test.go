@@ -0,0 +1,52 @@
+package command
+
+import (
+	"errors"
+	"os/exec"
+	"testing"
+
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Run(t *testing.T) {
+	t.Parallel()
+
+	logger, hook := test.NewNullLogger()
+
+	err := Run(exec.Command("sh", "-c", "echo progress; echo failure >&2; exit 3"), logger)
+
+	var cmdErr *Error
+	require.True(t, errors.As(err, &cmdErr))
+	assert.Equal(t, 3, cmdErr.ExitCode)
+	assert.Equal(t, "sh -c echo progress; echo failure >&2; exit 3", cmdErr.Command)
+	assert.Contains(t, cmdErr.Output, "failure")
+	assert.Len(t, hook.AllEntries(), 2)
+}
+
+func Test_RunNotFound(t *testing.T) {
+	t.Parallel()
+
+	err := Run(exec.Command("xk6-environment-no-such-command"), nil)
+
+	var cmdErr *Error
+	require.True(t, errors.As(err, &cmdErr))
+	assert.Equal(t, -1, cmdErr.ExitCode)
+	assert.ErrorIs(t, err, exec.ErrNotFound)
+}
+
+func Test_Output(t *testing.T) {
+	t.Parallel()
+
+	out, err := Output(exec.Command("sh", "-c", "echo result"))
+	require.NoError(t, err)
+	assert.Equal(t, "result\n", string(out))
+
+	_, err = Output(exec.Command("sh", "-c", "echo partial; echo failure >&2; exit 2"))
+
+	var cmdErr *Error
+	require.True(t, errors.As(err, &cmdErr))
+	assert.Equal(t, 2, cmdErr.ExitCode)
+	assert.Equal(t, "failure", cmdErr.Output)
+}
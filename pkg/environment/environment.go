@@ -5,18 +5,26 @@ package environment
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/grafana/xk6-environment/pkg/fs"
 	"github.com/grafana/xk6-environment/pkg/kubernetes"
-	"github.com/grafana/xk6-environment/pkg/vcluster"
+	"github.com/grafana/xk6-environment/pkg/provider"
 
+	"github.com/sirupsen/logrus"
 	"go.k6.io/k6/js/modules"
 	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/rest"
 )
 
 // Options for environment
@@ -41,138 +49,233 @@ type JSOptions struct {
 	Timeout        string // not supported yet: should be passed to waiting functions
 }
 
+//nolint:gochecknoglobals
+var (
+	tunnelsMu sync.Mutex
+	// tunnels opened by PortForward, by name of environment and by target;
+	// they are shared by all VUs
+	tunnels = map[string]map[string]*kubernetes.Tunnel{}
+
+	eventWatchesMu sync.Mutex
+	// eventWatches stop watches of events, by name of environment
+	eventWatches = map[string]context.CancelFunc{}
+)
+
 // Environment is the type for our custom API.
 type Environment struct {
 	VU modules.VU
 
 	opts             *options
 	kubernetesClient *kubernetes.Client
-	// This is now set from init context, see newEnvironment call
-	ParentContext string
-	TestName      string
-	envDesc       *fs.EnvDescription
+	provider         provider.Provider
+	TestName         string
+	envDesc          *fs.EnvDescription
 
 	// set from JS
 	JSOptions
+	// LogEvents selects Kubernetes events which are logged from Create
+	// until Delete; events are not logged if it's nil.
+	LogEvents *kubernetes.EventFilter
+
+	// clientClosed is closed when kubernetesClient can no longer reach
+	// the environment; it's nil if the client stays usable.
+	clientClosed <-chan struct{}
+
+	// k6Logger is the k6 logger; it can be nil.
+	k6Logger logrus.FieldLogger
 
 	// This is from k6-environment CLI:
 	// no logging is happening at the level of Environment here.
 	logger *zap.Logger
 }
 
-// NewEnvironment constructs a new Environment.
-func NewEnvironment(fenv *fs.EnvDescription, logger *zap.Logger) *Environment {
+// NewEnvironment constructs a new Environment with the given implementation,
+// which must be one of the registered implementations. params are passed on
+// to its Provider.
+func NewEnvironment(
+	implementation string, params provider.Params, fenv *fs.EnvDescription, logger *zap.Logger,
+) (*Environment, error) {
+	opts := &options{
+		params.ConfigPath,
+	}
+
+	params.Env = fenv
+
+	p, err := newProvider(implementation, params)
+	if err != nil {
+		return nil, err
+	}
+
 	return &Environment{
-		opts: &options{
-			"",
-		},
+		opts:             opts,
 		kubernetesClient: nil,
-		ParentContext:    "",
-		TestName:         "",
+		provider:         p,
+		TestName:         params.Name,
 		envDesc:          fenv,
+		k6Logger:         params.Logger,
 
 		logger: logger,
-	}
+	}, nil
 }
 
-// SetTestName set the name of environment.
-func (e *Environment) SetTestName(n string) {
-	e.TestName = n
-}
+// InitKubernetes builds a Kubernetes client to access the environment,
+// as given by its Provider. Kubeconfig of the user is never modified.
+// The client is built once and reused until the environment is deleted or,
+// for providers of ConnectionCloser, until its connection is closed.
+func (e *Environment) InitKubernetes(ctx context.Context) (err error) {
+	if e.kubernetesClient != nil {
+		select {
+		case <-e.clientClosed:
+			e.kubernetesClient, e.clientClosed = nil, nil
+		default:
+			return nil
+		}
+	}
 
-// InitKubernetes switches to the given Kubernetes context if provided and
-// builds a new Kubernetes client.
-func (e *Environment) InitKubernetes(ctx context.Context, ctxName string) (err error) {
-	if ctxName != "" {
-		if err := kubernetes.SetContext(e.opts.ConfigPath, ctxName); err != nil {
-			return err
+	switch g := e.provider.(type) {
+	case provider.ClientGetter:
+		if e.kubernetesClient, err = g.Client(ctx); err != nil {
+			return fmt.Errorf("%w: %w", ErrProvider, err)
+		}
+	case provider.RESTConfigGetter:
+		var restConfig *rest.Config
+		if restConfig, err = g.RESTConfig(ctx); err != nil {
+			return fmt.Errorf("%w: %w", ErrProvider, err)
 		}
+		e.kubernetesClient, err = kubernetes.NewClientForConfig(ctx, restConfig)
+		if c, ok := g.(provider.ConnectionCloser); ok && err == nil {
+			e.clientClosed = c.Closed(restConfig)
+		}
+	default:
+		e.kubernetesClient, err = kubernetes.NewClient(ctx, e.opts.ConfigPath)
+	}
+	if err != nil {
+		return err
 	}
 
-	e.kubernetesClient, err = kubernetes.NewClient(ctx, e.opts.ConfigPath)
-	return
-}
+	if m, ok := e.provider.(kubernetes.NamespaceMapper); ok {
+		e.kubernetesClient.SetNamespaceMapper(m)
+	}
 
-func (e *Environment) getParent(_ context.Context) (err error) {
-	e.ParentContext, err = kubernetes.CurrentContext("")
-	return
-}
+	if t, ok := e.provider.(kubernetes.ObjectTracker); ok {
+		e.kubernetesClient.SetObjectTracker(t)
+	}
 
-// In the end of each k6 lifecycle step, we should go back to parent
-// Kubernetes context, in order to have a clean state to start with
-func (e *Environment) parent(_ context.Context) error {
-	return kubernetes.SetContext(e.opts.ConfigPath, e.ParentContext)
+	return nil
 }
 
 // Describe returns a short text description of the Environment.
 func (e *Environment) Describe() string {
 	return fmt.Sprintf(`Test name: %s, with files: %+v. 
-						jsopts: %v,
-						K8s parent context: %s\n`,
-		e.TestName, e.envDesc, e.JSOptions, e.ParentContext)
+						jsopts: %v\n`,
+		e.TestName, e.envDesc, e.JSOptions)
 }
 
-// Create creates a vcluster and deploys the initial environment
-// according to user's configuration.
+// Create creates an environment with its Provider and deploys
+// the initial environment according to user's configuration.
 // Create is meant to be called in setup() of the script.
 func (e *Environment) Create(ctx context.Context) (err error) {
-	if err = e.getParent(ctx); err != nil {
-		return err
+	// the client of a previous environment with the same name is stale
+	e.kubernetesClient, e.clientClosed = nil, nil
+
+	if err = e.provider.Create(ctx); err != nil {
+		return fmt.Errorf("%w: %w", ErrProvider, err)
 	}
 
-	// always return to parent context so that
-	// the next operations can continue
-	defer func() {
-		e := e.parent(ctx)
-		// overwrite return value, only if it's nil;
-		// otherwise, return the error from main function
-		if err == nil {
-			err = e
-		}
-	}()
+	if err = e.InitKubernetes(ctx); err != nil {
+		return fmt.Errorf("unable to initialize Kubernetes client: %w", err)
+	}
 
-	if err = vcluster.Create(e.TestName); err != nil {
-		return
+	if err = e.kubernetesClient.Deploy(ctx, e.envDesc); err != nil {
+		return err
 	}
 
-	if err = e.InitKubernetes(ctx, e.TestName); err != nil {
-		return fmt.Errorf("unable to initialize Kubernetes client: %w", err)
+	if e.LogEvents != nil {
+		err = e.logEvents()
 	}
 
-	err = e.kubernetesClient.Deploy(ctx, e.envDesc)
 	return
 }
 
-// Delete deletes a vcluster.
+// logEvents starts to log Kubernetes events selected by LogEvents with
+// k6 logger, until the environment is deleted.
+func (e *Environment) logEvents() error {
+	if e.k6Logger == nil {
+		return nil
+	}
+
+	stopEvents(e.TestName)
+
+	// the watch outlives the call
+	watchCtx, cancel := context.WithCancel(context.Background())
+	logger := e.k6Logger.WithField("environment", e.TestName)
+
+	err := e.kubernetesClient.WatchEvents(watchCtx, *e.LogEvents, func(event kubernetes.Event) {
+		l := logger.WithFields(logrus.Fields{
+			"namespace": event.Namespace,
+			"kind":      event.Kind,
+			"name":      event.Name,
+			"type":      event.Type,
+			"reason":    event.Reason,
+			"count":     event.Count,
+		})
+
+		if event.Type == corev1.EventTypeWarning {
+			l.Warn(event.Message)
+		} else {
+			l.Info(event.Message)
+		}
+	})
+	if err != nil {
+		cancel()
+		return fmt.Errorf("unable to watch events: %w", err)
+	}
+
+	eventWatchesMu.Lock()
+	eventWatches[e.TestName] = cancel
+	eventWatchesMu.Unlock()
+
+	return nil
+}
+
+// stopEvents stops logging of events of the environment with the given name.
+func stopEvents(name string) {
+	eventWatchesMu.Lock()
+	defer eventWatchesMu.Unlock()
+
+	if cancel, ok := eventWatches[name]; ok {
+		cancel()
+		delete(eventWatches, name)
+	}
+}
+
+// Delete removes the environment with its Provider.
 // Delete is meant to be called in teardown() of the script.
-func (e *Environment) Delete(_ context.Context) error {
-	// This will be needed if / when vcluster is done via Helm
-	// if err := e.InitKubernetes(ctx, ""); err != nil {
-	// 	return fmt.Errorf("unable to initialize Kubernetes client: %w", err)
-	// }
+func (e *Environment) Delete(ctx context.Context) error {
+	e.kubernetesClient, e.clientClosed = nil, nil
+	closeTunnels(e.TestName)
+	stopEvents(e.TestName)
 
-	if err := vcluster.Delete(e.TestName); err != nil {
-		return err
+	if err := e.provider.Delete(ctx); err != nil {
+		return fmt.Errorf("%w: %w", ErrProvider, err)
+	}
+
+	return nil
+}
+
+// Status returns the current state of the environment.
+func (e *Environment) Status(ctx context.Context) (provider.Status, error) {
+	status, err := e.provider.Status(ctx)
+	if err != nil {
+		return status, fmt.Errorf("%w: %w", ErrProvider, err)
 	}
 
-	return kubernetes.DeleteContext(e.opts.ConfigPath, e.TestName)
+	return status, nil
 }
 
 // Wait blocks execution until given wait condition is reached.
 func (e *Environment) Wait(ctx context.Context, wc *kubernetes.WaitCondition) (err error) {
-	if err = e.getParent(ctx); err != nil {
-		return
-	}
-	defer func() {
-		e := e.parent(ctx)
-		// overwrite return value, only if it's nil;
-		// otherwise, return the error from main function
-		if err == nil {
-			err = e
-		}
-	}()
-
-	if err = e.InitKubernetes(ctx, e.TestName); err != nil {
+	if err = e.InitKubernetes(ctx); err != nil {
 		return fmt.Errorf("unable to initialize Kubernetes client: %w", err)
 	}
 
@@ -190,29 +293,26 @@ func newTestName(prefix string) string {
 
 // Apply deploys the manifest file.
 func (e *Environment) Apply(ctx context.Context, file string) error {
+	spec, err := ReadManifest(file)
+	if err != nil {
+		return err
+	}
+	return e.ApplySpec(ctx, spec)
+}
+
+// ReadManifest returns the content of the manifest file.
+func ReadManifest(file string) (string, error) {
 	//nolint:forbidigo
 	data, err := os.ReadFile(filepath.Clean(file))
 	if err != nil {
-		return err
+		return "", err
 	}
-	return e.ApplySpec(ctx, string(data))
+	return string(data), nil
 }
 
 // ApplySpec deploys the manifest spec.
 func (e *Environment) ApplySpec(ctx context.Context, spec string) (err error) {
-	if err = e.getParent(ctx); err != nil {
-		return
-	}
-	defer func() {
-		e := e.parent(ctx)
-		// overwrite return value, only if it's nil;
-		// otherwise, return the error from main function
-		if err == nil {
-			err = e
-		}
-	}()
-
-	if err = e.InitKubernetes(ctx, e.TestName); err != nil {
+	if err = e.InitKubernetes(ctx); err != nil {
 		return fmt.Errorf("unable to initialize Kubernetes client: %w", err)
 	}
 
@@ -220,47 +320,415 @@ func (e *Environment) ApplySpec(ctx context.Context, spec string) (err error) {
 	return err
 }
 
-// GetN returns number of objects within environment with the given parameters.
-func (e *Environment) GetN(ctx context.Context, optsArg map[string]interface{}) (n int, err error) {
-	if err = e.getParent(ctx); err != nil {
-		return
+// List returns objects of the given kind within environment. opts are
+// options of list: namespace, apiVersion, labelSelector, labels,
+// fieldSelector, allNamespaces and limit; any other key is invalid.
+func (e *Environment) List(
+	ctx context.Context, kind string, opts map[string]interface{},
+) ([]map[string]interface{}, error) {
+	apiVersion, listOpts, err := listOptions(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = e.InitKubernetes(ctx); err != nil {
+		return nil, fmt.Errorf("unable to initialize Kubernetes client: %w", err)
 	}
-	defer func() {
-		e := e.parent(ctx)
-		// overwrite return value, only if it's nil;
-		// otherwise, return the error from main function
-		if err == nil {
-			err = e
-		}
-	}()
 
-	if err = e.InitKubernetes(ctx, e.TestName); err != nil {
+	return e.kubernetesClient.List(ctx, apiVersion, kind, listOpts)
+}
+
+// GetN returns number of objects of the given kind within environment,
+// selected by opts as in List.
+func (e *Environment) GetN(ctx context.Context, kind string, opts map[string]interface{}) (int, error) {
+	apiVersion, listOpts, err := listOptions(opts)
+	if err != nil {
+		return 0, err
+	}
+
+	if err = e.InitKubernetes(ctx); err != nil {
 		return 0, fmt.Errorf("unable to initialize Kubernetes client: %w", err)
 	}
 
-	namespace, labels := namespaceAndLabels(optsArg)
+	return e.kubernetesClient.Count(ctx, apiVersion, kind, listOpts)
+}
 
-	n, err = e.kubernetesClient.GetN(ctx, namespace, &metav1.ListOptions{
-		LabelSelector: labels,
-	})
+// Get returns the object within environment as unstructured content.
+func (e *Environment) Get(ctx context.Context, ref kubernetes.ObjectRef) (map[string]interface{}, error) {
+	if err := e.InitKubernetes(ctx); err != nil {
+		return nil, fmt.Errorf("unable to initialize Kubernetes client: %w", err)
+	}
+
+	return e.kubernetesClient.Get(ctx, ref)
+}
+
+// SetStatus replaces .status of the object within environment.
+func (e *Environment) SetStatus(
+	ctx context.Context, ref kubernetes.ObjectRef, status map[string]interface{},
+) (err error) {
+	if err = e.InitKubernetes(ctx); err != nil {
+		return fmt.Errorf("unable to initialize Kubernetes client: %w", err)
+	}
+
+	err = e.kubernetesClient.SetStatus(ctx, ref, status)
 	return
 }
 
-func namespaceAndLabels(opts map[string]interface{}) (ns string, l string) {
-	var ok bool
-	if ns, ok = opts["namespace"].(string); !ok {
-		ns = "default"
+// DeleteResource removes the object within environment. opts are options of
+// deletion: propagationPolicy, ignoreNotFound, wait, interval and timeout.
+func (e *Environment) DeleteResource(
+	ctx context.Context, ref kubernetes.ObjectRef, opts map[string]interface{},
+) error {
+	deleteOpts, err := deleteOptions(opts)
+	if err != nil {
+		return err
+	}
+
+	if err = e.InitKubernetes(ctx); err != nil {
+		return fmt.Errorf("unable to initialize Kubernetes client: %w", err)
+	}
+
+	return e.kubernetesClient.Delete(ctx, ref, deleteOpts)
+}
+
+// DeleteSelected removes objects of the given kind within environment,
+// selected by selector as in List; label selector is required. opts are
+// options of deletion as in DeleteResource.
+func (e *Environment) DeleteSelected(
+	ctx context.Context, kind string, selector, opts map[string]interface{},
+) error {
+	apiVersion, listOpts, err := listOptions(selector)
+	if err != nil {
+		return err
+	}
+
+	deleteOpts, err := deleteOptions(opts)
+	if err != nil {
+		return err
 	}
 
+	if err = e.InitKubernetes(ctx); err != nil {
+		return fmt.Errorf("unable to initialize Kubernetes client: %w", err)
+	}
+
+	return e.kubernetesClient.DeleteSelected(ctx, apiVersion, kind, listOpts, deleteOpts)
+}
+
+// DeleteFile removes objects described by the manifest file.
+func (e *Environment) DeleteFile(ctx context.Context, file string, opts map[string]interface{}) error {
+	//nolint:forbidigo
+	data, err := os.ReadFile(filepath.Clean(file))
+	if err != nil {
+		return err
+	}
+	return e.DeleteSpec(ctx, string(data), opts)
+}
+
+// DeleteSpec removes objects described by the manifest spec, i.e. objects
+// deployed with ApplySpec. opts are options of deletion as in DeleteResource.
+func (e *Environment) DeleteSpec(ctx context.Context, spec string, opts map[string]interface{}) error {
+	deleteOpts, err := deleteOptions(opts)
+	if err != nil {
+		return err
+	}
+
+	if err = e.InitKubernetes(ctx); err != nil {
+		return fmt.Errorf("unable to initialize Kubernetes client: %w", err)
+	}
+
+	return e.kubernetesClient.DeleteSpec(ctx, bytes.NewBufferString(spec), deleteOpts)
+}
+
+// Patch changes the object within environment with patch and returns the
+// result. patch is either a JSON string or a value to be marshaled to JSON.
+// opts are options of patch: type ("merge", "json", "strategic" or "apply")
+// and subresource ("status" or "scale").
+func (e *Environment) Patch(
+	ctx context.Context, ref kubernetes.ObjectRef, patch interface{}, opts map[string]interface{},
+) (map[string]interface{}, error) {
+	patchOpts, err := patchOptions(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	data, ok := patch.(string)
+	if !ok {
+		b, err := json.Marshal(patch)
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid patch: %w", ErrValidation, err)
+		}
+		data = string(b)
+	}
+
+	if err = e.InitKubernetes(ctx); err != nil {
+		return nil, fmt.Errorf("unable to initialize Kubernetes client: %w", err)
+	}
+
+	return e.kubernetesClient.Patch(ctx, ref, []byte(data), patchOpts)
+}
+
+// Scale sets the number of replicas of the object within environment.
+func (e *Environment) Scale(ctx context.Context, ref kubernetes.ObjectRef, replicas int32) (err error) {
+	if err = e.InitKubernetes(ctx); err != nil {
+		return fmt.Errorf("unable to initialize Kubernetes client: %w", err)
+	}
+
+	err = e.kubernetesClient.Scale(ctx, ref, replicas)
+	return
+}
+
+// RolloutRestart restarts rollout of the object within environment.
+// Completion of rollout can be awaited with Wait and kubernetes.NewRolloutCondition.
+func (e *Environment) RolloutRestart(ctx context.Context, ref kubernetes.ObjectRef) (err error) {
+	if err = e.InitKubernetes(ctx); err != nil {
+		return fmt.Errorf("unable to initialize Kubernetes client: %w", err)
+	}
+
+	err = e.kubernetesClient.RolloutRestart(ctx, ref)
+	return
+}
+
+// Exec runs the command in a container of the pod within environment.
+func (e *Environment) Exec(
+	ctx context.Context, namespace, pod string, command []string, opts kubernetes.ExecOptions,
+) (*kubernetes.ExecResult, error) {
+	if err := e.InitKubernetes(ctx); err != nil {
+		return nil, fmt.Errorf("unable to initialize Kubernetes client: %w", err)
+	}
+
+	return e.kubernetesClient.Exec(ctx, namespace, pod, command, opts)
+}
+
+// Logs returns logs of a container of the pod within environment.
+func (e *Environment) Logs(ctx context.Context, namespace, pod string, opts kubernetes.LogOptions) (string, error) {
+	if err := e.InitKubernetes(ctx); err != nil {
+		return "", fmt.Errorf("unable to initialize Kubernetes client: %w", err)
+	}
+
+	return e.kubernetesClient.Logs(ctx, namespace, pod, opts)
+}
+
+// SelectedLogs returns logs of pods within environment selected by labels,
+// by name of pod.
+func (e *Environment) SelectedLogs(
+	ctx context.Context, namespace, selector string, opts kubernetes.LogOptions,
+) (map[string]string, error) {
+	if err := e.InitKubernetes(ctx); err != nil {
+		return nil, fmt.Errorf("unable to initialize Kubernetes client: %w", err)
+	}
+
+	return e.kubernetesClient.SelectedLogs(ctx, namespace, selector, opts)
+}
+
+// PortForward returns the local URL of a tunnel to the port of the service
+// or, if service is empty, of the pod within environment. Tunnels are shared
+// by all VUs and stay open until the environment is deleted.
+func (e *Environment) PortForward(ctx context.Context, namespace, service, pod string, port int) (string, error) {
+	target := fmt.Sprintf("pod %s/%s:%d", namespace, pod, port)
+	if len(service) > 0 {
+		target = fmt.Sprintf("service %s/%s:%d", namespace, service, port)
+	}
+
+	tunnelsMu.Lock()
+	defer tunnelsMu.Unlock()
+
+	if t, ok := tunnels[e.TestName][target]; ok {
+		return t.URL("http"), nil
+	}
+
+	if err := e.InitKubernetes(ctx); err != nil {
+		return "", fmt.Errorf("unable to initialize Kubernetes client: %w", err)
+	}
+
+	var (
+		t   *kubernetes.Tunnel
+		err error
+	)
+	if len(service) > 0 {
+		t, err = e.kubernetesClient.ForwardService(ctx, namespace, service, port)
+	} else {
+		t, err = e.kubernetesClient.ForwardPod(ctx, namespace, pod, port)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	if tunnels[e.TestName] == nil {
+		tunnels[e.TestName] = make(map[string]*kubernetes.Tunnel)
+	}
+	tunnels[e.TestName][target] = t
+
+	return t.URL("http"), nil
+}
+
+// closeTunnels closes all tunnels of the environment with the given name.
+func closeTunnels(name string) {
+	tunnelsMu.Lock()
+	defer tunnelsMu.Unlock()
+
+	for _, t := range tunnels[name] {
+		t.Close()
+	}
+	delete(tunnels, name)
+}
+
+// CopyTo copies the local file or directory into a container of the pod
+// within environment.
+func (e *Environment) CopyTo(
+	ctx context.Context, namespace, pod, localPath, remotePath string, opts kubernetes.CopyOptions,
+) error {
+	if err := e.InitKubernetes(ctx); err != nil {
+		return fmt.Errorf("unable to initialize Kubernetes client: %w", err)
+	}
+
+	return e.kubernetesClient.CopyTo(ctx, namespace, pod, localPath, remotePath, opts)
+}
+
+// CopyFrom copies the file or directory from a container of the pod within
+// environment.
+func (e *Environment) CopyFrom(
+	ctx context.Context, namespace, pod, remotePath, localPath string, opts kubernetes.CopyOptions,
+) error {
+	if err := e.InitKubernetes(ctx); err != nil {
+		return fmt.Errorf("unable to initialize Kubernetes client: %w", err)
+	}
+
+	return e.kubernetesClient.CopyFrom(ctx, namespace, pod, remotePath, localPath, opts)
+}
+
+func listOptions(opts map[string]interface{}) (apiVersion string, listOpts kubernetes.ListOptions, err error) {
+	var labels []string
+
 	for k, v := range opts {
-		if k == "namespace" {
-			continue
+		var ok bool
+		switch k {
+		case "namespace":
+			listOpts.Namespace, ok = v.(string)
+		case "apiVersion":
+			apiVersion, ok = v.(string)
+		case "labelSelector":
+			listOpts.LabelSelector, ok = v.(string)
+		case "fieldSelector":
+			listOpts.FieldSelector, ok = v.(string)
+		case "allNamespaces":
+			listOpts.AllNamespaces, ok = v.(bool)
+		case "limit":
+			switch limit := v.(type) {
+			case int64:
+				listOpts.Limit, ok = limit, true
+			case float64:
+				listOpts.Limit, ok = int64(limit), true
+			}
+		case "labels":
+			labels, ok = matchLabels(v)
+		default:
+			return "", listOpts, fmt.Errorf("%w: unknown %q in options of list", ErrValidation, k)
 		}
-		if len(l) > 0 {
-			l += ","
+
+		if !ok {
+			return "", listOpts, fmt.Errorf("%w: invalid %q in options of list: %+v", ErrValidation, k, v)
 		}
-		l += fmt.Sprintf("%s=%s", k, v)
 	}
 
-	return
+	if len(labels) > 0 {
+		// order of keys in map is random
+		sort.Strings(labels)
+		if len(listOpts.LabelSelector) > 0 {
+			labels = append([]string{listOpts.LabelSelector}, labels...)
+		}
+		listOpts.LabelSelector = strings.Join(labels, ",")
+	}
+
+	return apiVersion, listOpts, nil
+}
+
+// matchLabels converts labels given as an object, like {app: "nginx"},
+// into requirements of label selector, like "app=nginx".
+func matchLabels(v interface{}) ([]string, bool) {
+	m, ok := v.(map[string]interface{})
+	if !ok {
+		return nil, false
+	}
+
+	labels := make([]string, 0, len(m))
+	for k, v := range m {
+		value, ok := v.(string)
+		if !ok {
+			return nil, false
+		}
+		labels = append(labels, k+"="+value)
+	}
+
+	return labels, true
+}
+
+func deleteOptions(opts map[string]interface{}) (deleteOpts kubernetes.DeleteOptions, err error) {
+	for k, v := range opts {
+		var ok bool
+		switch k {
+		case "propagationPolicy":
+			var policy string
+			policy, ok = v.(string)
+			deleteOpts.PropagationPolicy = metav1.DeletionPropagation(policy)
+			switch deleteOpts.PropagationPolicy {
+			case metav1.DeletePropagationBackground, metav1.DeletePropagationForeground, metav1.DeletePropagationOrphan:
+			default:
+				ok = false
+			}
+		case "ignoreNotFound":
+			deleteOpts.IgnoreNotFound, ok = v.(bool)
+		case "wait":
+			deleteOpts.Wait, ok = v.(bool)
+		case "interval", "timeout":
+			var d time.Duration
+			if s, isString := v.(string); isString {
+				d, err = time.ParseDuration(s)
+				ok = err == nil
+			}
+			if k == "interval" {
+				deleteOpts.Interval = d
+			} else {
+				deleteOpts.Timeout = d
+			}
+		default:
+			return deleteOpts, fmt.Errorf("%w: unknown %q in options of deletion", ErrValidation, k)
+		}
+
+		if !ok {
+			return deleteOpts, fmt.Errorf("%w: invalid %q in options of deletion: %+v", ErrValidation, k, v)
+		}
+	}
+
+	return deleteOpts, nil
+}
+
+//nolint:gochecknoglobals
+var patchTypes = map[string]types.PatchType{
+	"merge":     types.MergePatchType,
+	"json":      types.JSONPatchType,
+	"strategic": types.StrategicMergePatchType,
+	"apply":     types.ApplyPatchType,
+}
+
+func patchOptions(opts map[string]interface{}) (patchOpts kubernetes.PatchOptions, err error) {
+	for k, v := range opts {
+		var ok bool
+		switch k {
+		case "type":
+			var patchType string
+			if patchType, ok = v.(string); ok {
+				patchOpts.Type, ok = patchTypes[patchType]
+			}
+		case "subresource":
+			patchOpts.Subresource, ok = v.(string)
+			ok = ok && (patchOpts.Subresource == kubernetes.SubresourceStatus ||
+				patchOpts.Subresource == kubernetes.SubresourceScale)
+		}
+
+		if !ok {
+			return patchOpts, fmt.Errorf("%w: invalid %q in options of patch: %+v", ErrValidation, k, v)
+		}
+	}
+
+	return patchOpts, nil
 }
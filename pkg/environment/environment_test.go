@@ -0,0 +1,110 @@
+package environment
+
+import (
+	"context"
+	"testing"
+
+	"github.com/grafana/xk6-environment/pkg/kubernetes"
+	"github.com/grafana/xk6-environment/pkg/provider"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/rest"
+)
+
+func Test_listOptions(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name       string
+		opts       map[string]interface{}
+		apiVersion string
+		listOpts   kubernetes.ListOptions
+		valid      bool
+	}{
+		{
+			name: "all options",
+			opts: map[string]interface{}{
+				"namespace": "ns", "apiVersion": "apps/v1", "labelSelector": "app=nginx",
+				"fieldSelector": "metadata.name=nginx", "allNamespaces": true, "limit": int64(10),
+			},
+			apiVersion: "apps/v1",
+			listOpts: kubernetes.ListOptions{
+				Namespace: "ns", LabelSelector: "app=nginx", FieldSelector: "metadata.name=nginx",
+				AllNamespaces: true, Limit: 10,
+			},
+			valid: true,
+		},
+		{
+			name: "labels",
+			opts: map[string]interface{}{
+				"namespace": "ns", "labels": map[string]interface{}{"tier": "web", "app": "nginx"}, "labelSelector": "env",
+			},
+			listOpts: kubernetes.ListOptions{Namespace: "ns", LabelSelector: "env,app=nginx,tier=web"},
+			valid:    true,
+		},
+		{
+			name: "labels not strings",
+			opts: map[string]interface{}{"labels": map[string]interface{}{"replicas": int64(1)}},
+		},
+		{
+			name: "unknown key",
+			opts: map[string]interface{}{"namespace": "ns", "app": "nginx"},
+		},
+		{
+			name: "invalid limit",
+			opts: map[string]interface{}{"limit": "10"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			apiVersion, listOpts, err := listOptions(testCase.opts)
+			if !testCase.valid {
+				assert.ErrorIs(t, err, ErrValidation)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, testCase.apiVersion, apiVersion)
+			assert.Equal(t, testCase.listOpts, listOpts)
+		})
+	}
+}
+
+// closingProvider gives access to the environment through a connection
+// which is closed by closing its channel.
+type closingProvider struct {
+	provider.Provider
+	closed chan struct{}
+}
+
+func (p *closingProvider) RESTConfig(_ context.Context) (*rest.Config, error) {
+	return &rest.Config{Host: "https://localhost:8443"}, nil
+}
+
+func (p *closingProvider) Closed(_ *rest.Config) <-chan struct{} {
+	return p.closed
+}
+
+func Test_InitKubernetesAfterClose(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	p := &closingProvider{closed: make(chan struct{})}
+	e := &Environment{provider: p}
+
+	require.NoError(t, e.InitKubernetes(ctx))
+	c := e.kubernetesClient
+
+	// the client is reused while the connection is open
+	require.NoError(t, e.InitKubernetes(ctx))
+	assert.Same(t, c, e.kubernetesClient)
+
+	close(p.closed)
+	p.closed = make(chan struct{})
+
+	require.NoError(t, e.InitKubernetes(ctx))
+	assert.NotSame(t, c, e.kubernetesClient)
+}
@@ -0,0 +1,14 @@
+package environment
+
+import (
+	"errors"
+)
+
+var (
+	// ErrValidation is returned when parameters of Environment or
+	// of its methods are invalid.
+	ErrValidation = errors.New("invalid parameters")
+	// ErrProvider is returned when the implementation of Environment
+	// fails to create, delete or give access to the environment.
+	ErrProvider = errors.New("environment provider failed")
+)
@@ -0,0 +1,68 @@
+package environment
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/grafana/xk6-environment/pkg/envtest"
+	"github.com/grafana/xk6-environment/pkg/existing"
+	"github.com/grafana/xk6-environment/pkg/fake"
+	"github.com/grafana/xk6-environment/pkg/kwok"
+	"github.com/grafana/xk6-environment/pkg/namespace"
+	"github.com/grafana/xk6-environment/pkg/provider"
+	"github.com/grafana/xk6-environment/pkg/vcluster"
+)
+
+// DefaultImplementation is used when implementation of Environment
+// is not specified.
+const DefaultImplementation = "vcluster"
+
+// providers is a registry of known implementations of Environment,
+// keyed by the value of "implementation" parameter.
+//
+//nolint:gochecknoglobals
+var providers = map[string]provider.Factory{
+	"vcluster":  vcluster.NewProvider,
+	"namespace": namespace.NewProvider,
+	"envtest":   envtest.NewProvider,
+	"fake":      fake.NewProvider,
+	"kwok":      kwok.NewProvider,
+	"existing":  existing.NewProvider,
+}
+
+// RegisterProvider makes a Provider available by the given implementation name.
+func RegisterProvider(implementation string, factory provider.Factory) {
+	providers[implementation] = factory
+}
+
+// Implementations returns sorted names of all registered implementations.
+func Implementations() []string {
+	names := make([]string, 0, len(providers))
+	for name := range providers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+func newProvider(implementation string, params provider.Params) (provider.Provider, error) {
+	if len(strings.TrimSpace(implementation)) == 0 {
+		return nil, fmt.Errorf("%w: implementation must not be empty; supported implementations are: %s",
+			ErrValidation, strings.Join(Implementations(), ", "))
+	}
+
+	factory, ok := providers[implementation]
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown implementation %q; supported implementations are: %s",
+			ErrValidation, implementation, strings.Join(Implementations(), ", "))
+	}
+
+	p, err := factory(params)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrValidation, err)
+	}
+
+	return p, nil
+}
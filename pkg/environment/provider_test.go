@@ -0,0 +1,56 @@
+package environment
+
+import (
+	"testing"
+
+	"github.com/grafana/xk6-environment/pkg/provider"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_newProvider(t *testing.T) {
+	testCases := []struct {
+		name           string
+		implementation string
+		valid          bool
+	}{
+		{
+			"empty implementation is rejected",
+			"",
+			false,
+		},
+		{
+			"blank implementation is rejected",
+			" \t",
+			false,
+		},
+		{
+			"vcluster is registered",
+			"vcluster",
+			true,
+		},
+		{
+			"unknown implementation is rejected",
+			"unknown",
+			false,
+		},
+	}
+
+	t.Parallel()
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			p, err := newProvider(testCase.implementation, provider.Params{
+				Name:    "test",
+				Options: map[string]interface{}{"vcluster": map[string]interface{}{"chart": "vcluster.tgz"}},
+			})
+			if testCase.valid {
+				assert.NoError(t, err)
+				assert.NotNil(t, p)
+			} else {
+				assert.ErrorIs(t, err, ErrValidation)
+				assert.ErrorContains(t, err, "vcluster")
+			}
+		})
+	}
+}
@@ -0,0 +1,115 @@
+// Package envtest provides an implementation of environment that runs
+// a local control plane (kube-apiserver and etcd) with envtest.
+package envtest
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/grafana/xk6-environment/pkg/provider"
+
+	"k8s.io/client-go/rest"
+	crenvtest "sigs.k8s.io/controller-runtime/pkg/envtest"
+)
+
+// Control planes are started in one k6 VU and used or stopped in
+// others, so they are kept per process, keyed by the name of environment.
+//
+//nolint:gochecknoglobals
+var (
+	mu            sync.Mutex
+	controlPlanes = map[string]*crenvtest.Environment{}
+)
+
+// Provider creates environment as a local control plane, started from
+// binaries located in binaryAssetsDirectory. If the directory is not
+// configured, envtest looks up KUBEBUILDER_ASSETS environment variable.
+type Provider struct {
+	name                  string
+	binaryAssetsDirectory string
+}
+
+var (
+	_ provider.Provider         = (*Provider)(nil)
+	_ provider.RESTConfigGetter = (*Provider)(nil)
+)
+
+// NewProvider constructs an envtest Provider. It can be configured with
+// "envtest" object in parameters of Environment:
+//
+//	envtest: { binaryAssetsDirectory: "/usr/local/kubebuilder/bin" }
+func NewProvider(params provider.Params) (provider.Provider, error) {
+	p := &Provider{
+		name: params.Name,
+	}
+
+	if opts, ok := params.Options["envtest"]; ok {
+		m, ok := opts.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf(`"envtest" must be an object of the form {binaryAssetsDirectory: "dir"}; got: %+v`, opts)
+		}
+		p.binaryAssetsDirectory, _ = m["binaryAssetsDirectory"].(string)
+	}
+
+	return p, nil
+}
+
+// Create starts the control plane.
+func (p *Provider) Create(_ context.Context) error {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if _, ok := controlPlanes[p.name]; ok {
+		return fmt.Errorf("envtest environment %s is already running", p.name)
+	}
+
+	te := &crenvtest.Environment{
+		BinaryAssetsDirectory: p.binaryAssetsDirectory,
+	}
+	if _, err := te.Start(); err != nil {
+		return fmt.Errorf("unable to start envtest control plane: %w", err)
+	}
+
+	controlPlanes[p.name] = te
+	return nil
+}
+
+// Delete stops the control plane.
+func (p *Provider) Delete(_ context.Context) error {
+	mu.Lock()
+	defer mu.Unlock()
+
+	te, ok := controlPlanes[p.name]
+	if !ok {
+		return fmt.Errorf("envtest environment %s is not running", p.name)
+	}
+
+	delete(controlPlanes, p.name)
+	return te.Stop()
+}
+
+// Status returns running if the control plane was started in this process.
+func (p *Provider) Status(_ context.Context) (provider.Status, error) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if _, ok := controlPlanes[p.name]; ok {
+		return provider.StatusRunning, nil
+	}
+
+	return provider.StatusNotFound, nil
+}
+
+// RESTConfig returns configuration to access the control plane.
+func (p *Provider) RESTConfig(_ context.Context) (*rest.Config, error) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	te, ok := controlPlanes[p.name]
+	if !ok {
+		return nil, fmt.Errorf("envtest environment %s is not running", p.name)
+	}
+
+	return rest.CopyConfig(te.Config), nil
+}
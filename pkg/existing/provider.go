@@ -0,0 +1,129 @@
+// Package existing provides an implementation of environment that uses
+// an existing Kubernetes cluster without creating or removing it.
+package existing
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/grafana/xk6-environment/pkg/kubernetes"
+	"github.com/grafana/xk6-environment/pkg/provider"
+)
+
+// Provider uses the cluster of the given Kubernetes context as environment.
+// Objects created by the environment are labelled, so that only they are
+// removed on deletion of the environment.
+type Provider struct {
+	name       string
+	configPath string
+	context    string
+}
+
+var (
+	trackedMu sync.Mutex
+	// resources where objects were created, by name of environment;
+	// kept per process, as init and delete may be called from different VUs
+	tracked = map[string]map[kubernetes.TrackedResource]struct{}{}
+)
+
+var (
+	_ provider.Provider        = (*Provider)(nil)
+	_ provider.ClientGetter    = (*Provider)(nil)
+	_ kubernetes.ObjectTracker = (*Provider)(nil)
+)
+
+// NewProvider constructs an existing Provider. The context can be configured
+// with "context" parameter of Environment; by default, the current context is used.
+func NewProvider(params provider.Params) (provider.Provider, error) {
+	p := &Provider{
+		name:       params.Name,
+		configPath: params.ConfigPath,
+	}
+
+	if v, ok := params.Options["context"]; ok {
+		if p.context, ok = v.(string); !ok {
+			return nil, fmt.Errorf(`"context" must be a string; got: %+v`, v)
+		}
+	}
+
+	return p, nil
+}
+
+// Client returns the client for the configured context, without switching
+// the current context of Kubeconfig.
+func (p *Provider) Client(ctx context.Context) (*kubernetes.Client, error) {
+	if len(p.context) == 0 {
+		return kubernetes.NewClient(ctx, p.configPath)
+	}
+
+	return kubernetes.NewClientForContext(ctx, p.configPath, p.context)
+}
+
+// Create only checks that the cluster is reachable.
+func (p *Provider) Create(ctx context.Context) error {
+	c, err := p.Client(ctx)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.ServerVersion()
+	return err
+}
+
+// Delete removes objects created by the environment, but not the cluster.
+func (p *Provider) Delete(ctx context.Context) error {
+	c, err := p.Client(ctx)
+	if err != nil {
+		return err
+	}
+
+	trackedMu.Lock()
+	defer trackedMu.Unlock()
+
+	resources := make([]kubernetes.TrackedResource, 0, len(tracked[p.name]))
+	for r := range tracked[p.name] {
+		resources = append(resources, r)
+	}
+
+	if err = c.DeleteLabelled(ctx, p.TrackingLabels(), resources); err != nil {
+		return err
+	}
+
+	delete(tracked, p.name)
+
+	return nil
+}
+
+// Status returns running if the cluster is reachable.
+func (p *Provider) Status(ctx context.Context) (provider.Status, error) {
+	c, err := p.Client(ctx)
+	if err != nil {
+		return provider.StatusUnknown, err
+	}
+
+	if _, err = c.ServerVersion(); err != nil {
+		return provider.StatusUnknown, err
+	}
+
+	return provider.StatusRunning, nil
+}
+
+// TrackingLabels returns labels that mark objects created by the environment.
+func (p *Provider) TrackingLabels() map[string]string {
+	return map[string]string{
+		kubernetes.EnvironmentLabel: p.name,
+	}
+}
+
+// Track records the resource and the namespace of an object created by the
+// environment, so that Delete searches only there.
+func (p *Provider) Track(r kubernetes.TrackedResource) {
+	trackedMu.Lock()
+	defer trackedMu.Unlock()
+
+	if tracked[p.name] == nil {
+		tracked[p.name] = make(map[kubernetes.TrackedResource]struct{})
+	}
+	tracked[p.name][r] = struct{}{}
+}
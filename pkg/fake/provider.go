@@ -0,0 +1,96 @@
+// Package fake provides an implementation of environment that keeps
+// all Kubernetes objects in memory, without access to any cluster.
+package fake
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/grafana/xk6-environment/pkg/kubernetes"
+	"github.com/grafana/xk6-environment/pkg/provider"
+)
+
+// Fake environments are created in one k6 VU and used or deleted in
+// others, so they are kept per process, keyed by the name of environment.
+//
+//nolint:gochecknoglobals
+var (
+	mu      sync.Mutex
+	clients = map[string]*kubernetes.Client{}
+)
+
+// Provider creates environment as an in-memory fake Kubernetes client.
+// There are no controllers in such environment: .status of objects
+// changes only when it's set explicitly.
+type Provider struct {
+	name string
+}
+
+var (
+	_ provider.Provider     = (*Provider)(nil)
+	_ provider.ClientGetter = (*Provider)(nil)
+)
+
+// NewProvider constructs a fake Provider.
+func NewProvider(params provider.Params) (provider.Provider, error) {
+	return &Provider{
+		name: params.Name,
+	}, nil
+}
+
+// Create constructs a new fake client.
+func (p *Provider) Create(_ context.Context) error {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if _, ok := clients[p.name]; ok {
+		return fmt.Errorf("fake environment %s already exists", p.name)
+	}
+
+	c, err := kubernetes.NewFakeClient()
+	if err != nil {
+		return err
+	}
+
+	clients[p.name] = c
+	return nil
+}
+
+// Delete drops the fake client together with all objects in it.
+func (p *Provider) Delete(_ context.Context) error {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if _, ok := clients[p.name]; !ok {
+		return fmt.Errorf("fake environment %s doesn't exist", p.name)
+	}
+
+	delete(clients, p.name)
+	return nil
+}
+
+// Status returns running if the fake environment was created in this process.
+func (p *Provider) Status(_ context.Context) (provider.Status, error) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if _, ok := clients[p.name]; ok {
+		return provider.StatusRunning, nil
+	}
+
+	return provider.StatusNotFound, nil
+}
+
+// Client returns the fake client of the environment.
+func (p *Provider) Client(_ context.Context) (*kubernetes.Client, error) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	c, ok := clients[p.name]
+	if !ok {
+		return nil, fmt.Errorf("fake environment %s doesn't exist", p.name)
+	}
+
+	return c, nil
+}
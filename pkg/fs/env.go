@@ -11,6 +11,10 @@ import (
 type EnvDescription struct {
 	Manifests    []string
 	KustomizeDir string
+	// VClusterValuesFile is the path to vcluster.yaml at the top
+	// of init folder, if present. It is not a manifest but
+	// a values file of vcluster chart.
+	VClusterValuesFile string
 
 	kustomizationPresent bool
 	folder               string
@@ -7,8 +7,13 @@ import (
 	"path/filepath"
 )
 
+// vclusterValuesFile is the name of the values file of vcluster chart
+// which can be placed at the top of init folder.
+const vclusterValuesFile = "vcluster.yaml"
+
 // FindEnv walks the folder and locates Kubernetes manifests
-// as an environment description.
+// as an environment description. vcluster.yaml at the top
+// of the folder is treated as a values file of vcluster.
 func FindEnv(folder string) (*EnvDescription, error) {
 	f := &EnvDescription{}
 	if len(folder) == 0 {
@@ -27,11 +32,14 @@ func FindEnv(folder string) (*EnvDescription, error) {
 		}
 
 		if ext := filepath.Ext(path); ext == ".yaml" || ext == ".yml" {
-			if filepath.Base(path) == "kustomization.yaml" {
+			switch {
+			case path == vclusterValuesFile:
+				f.VClusterValuesFile = filepath.Join(folder, path)
+			case filepath.Base(path) == "kustomization.yaml":
 				f.kustomizationPresent = true
 				relativePath, _ := filepath.Split(path)
 				f.KustomizeDir = folder + relativePath
-			} else {
+			default:
 				manifests = append(manifests, path)
 			}
 		}
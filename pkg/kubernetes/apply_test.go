@@ -0,0 +1,71 @@
+package kubernetes
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const testMultiDocument = `---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: first
+---
+---
+apiVersion: v1
+kind: List
+items:
+- apiVersion: v1
+  kind: ConfigMap
+  metadata:
+    name: second
+- apiVersion: v1
+  kind: ConfigMap
+  metadata:
+    name: third
+    namespace: other
+---
+apiVersion: example.com/v1
+kind: Unknown
+metadata:
+  name: broken
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: fourth
+`
+
+func Test_ApplyMultiDocument(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	c, err := NewFakeClient()
+	require.NoError(t, err)
+
+	err = c.Apply(ctx, bytes.NewBufferString(testMultiDocument))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "document 3: example.com/v1 Unknown broken")
+
+	// documents around the broken one are applied
+	for ns, names := range map[string][]string{"default": {"first", "second", "fourth"}, "other": {"third"}} {
+		for _, name := range names {
+			ri, err := c.resource("", "ConfigMap", ns)
+			require.NoError(t, err)
+			_, err = ri.Get(ctx, name, metav1.GetOptions{})
+			assert.NoError(t, err, name)
+		}
+	}
+}
+
+func Test_Kinds(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, []string{"ConfigMap", "Unknown"}, Kinds(testMultiDocument))
+	assert.Empty(t, Kinds("not: [a manifest"))
+}
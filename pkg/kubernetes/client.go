@@ -5,13 +5,14 @@ package kubernetes
 import (
 	"bytes"
 	"context"
-	"encoding/json"
 	"errors"
 	"fmt"
-	"path/filepath"
+	"io"
+	"sort"
 
 	"github.com/grafana/xk6-environment/pkg/fs"
 
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -25,69 +26,129 @@ import (
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/restmapper"
 	"k8s.io/client-go/tools/clientcmd"
-	"k8s.io/client-go/util/homedir"
 
 	crclient "sigs.k8s.io/controller-runtime/pkg/client"
 )
 
-func loadConfig(configPath string) (clientcmd.ClientConfig, error) {
-	kubeconfig := configPath
-	if kubeconfig == "" {
-		home := homedir.HomeDir()
-		if home == "" {
-			return nil, errors.New("home directory not found")
-		}
-		kubeconfig = filepath.Join(home, ".kube", "config")
-	}
-
-	configLoadingRules := &clientcmd.ClientConfigLoadingRules{ExplicitPath: kubeconfig}
+// loadConfig returns Kubeconfig at configPath. If configPath is empty, Kubeconfig
+// is looked up as kubectl does: KUBECONFIG environment variable, which can be
+// a list of files to merge, or ~/.kube/config.
+func loadConfig(configPath string) clientcmd.ClientConfig {
+	configLoadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
+	configLoadingRules.ExplicitPath = configPath
 
 	return clientcmd.NewNonInteractiveDeferredLoadingClientConfig(
 		configLoadingRules,
 		&clientcmd.ConfigOverrides{
 			CurrentContext: "",
-		}), nil
+		})
 }
 
 func getClientConfig(configPath string) (*rest.Config, error) {
-	cfg, err := loadConfig(configPath)
-	if err != nil {
-		return nil, err
-	}
-
-	return cfg.ClientConfig()
+	return loadConfig(configPath).ClientConfig()
 }
 
 // Client encapsulates the key structures of Kubernetes libraries
 // that are used to access Kubernetes.
 type Client struct {
-	discoveryClient *discovery.DiscoveryClient
+	discoveryClient discovery.CachedDiscoveryInterface
 	configPath      string
 	restConfig      *rest.Config
-	clientset       *k8s.Clientset
-	dynamicClient   *dynamic.DynamicClient
-	restMapper      *restmapper.DeferredDiscoveryRESTMapper
+	clientset       k8s.Interface
+	dynamicClient   dynamic.Interface
+	restMapper      meta.RESTMapper
 	crClient        crclient.Client
+
+	// applyMapper is used to find REST mapping of objects in Apply
+	applyMapper meta.RESTMapper
+	// apply is server side apply unless it's a fake Client
+	apply func(ctx context.Context, obj *unstructured.Unstructured, mapping *meta.RESTMapping) error
+	// listMetadata is done with controller-runtime client unless it's a fake Client
+	listMetadata func(
+		ctx context.Context, mapping *meta.RESTMapping, namespace string, opts metav1.ListOptions,
+	) (*metav1.PartialObjectMetadataList, error)
+	// logs are read with clientset; the fake Client checks the pod first
+	logs func(ctx context.Context, namespace, pod string, opts *corev1.PodLogOptions) ([]byte, error)
+	// patch is done with controller-runtime client unless it's a fake Client
+	patch func(
+		ctx context.Context, obj *unstructured.Unstructured, mapping *meta.RESTMapping,
+		patch crclient.Patch, subresource string,
+	) (map[string]interface{}, error)
+
+	namespaceMapper NamespaceMapper
+	// namespaces created by Client with namespaceMapper
+	namespaces map[string]struct{}
+	// labels set on created objects, see ObjectTracker
+	trackingLabels map[string]string
+	tracker        ObjectTracker
+}
+
+// NewClient constructs the Client from Kubeconfig at configPath.
+func NewClient(ctx context.Context, configPath string) (*Client, error) {
+	restConfig, err := getClientConfig(configPath)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := NewClientForConfig(ctx, restConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	client.configPath = configPath
+	return client, nil
+}
+
+// NewClientForContext constructs the Client for the given context in
+// Kubeconfig at configPath, without switching the current context.
+func NewClientForContext(ctx context.Context, configPath, ctxName string) (*Client, error) {
+	restConfig, err := RESTConfigForContext(configPath, ctxName)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := NewClientForConfig(ctx, restConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	client.configPath = configPath
+	return client, nil
+}
+
+// RESTConfigForContext returns configuration of the given context in
+// Kubeconfig at configPath. Kubeconfig is only read, never modified.
+func RESTConfigForContext(configPath, ctxName string) (*rest.Config, error) {
+	rawConfig, err := loadConfig(configPath).RawConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	if rawConfig.Contexts[ctxName] == nil {
+		return nil, fmt.Errorf("context %s doesn't exist", ctxName)
+	}
+
+	return clientcmd.NewNonInteractiveClientConfig(
+		rawConfig, ctxName, &clientcmd.ConfigOverrides{}, nil).ClientConfig()
 }
 
-// NewClient constructs the Client.
-func NewClient(_ context.Context, configPath string) (*Client, error) {
+// NewClientForConfig constructs the Client from the given rest.Config,
+// without accessing Kubeconfig.
+func NewClientForConfig(_ context.Context, restConfig *rest.Config) (*Client, error) {
 	var (
 		client = &Client{
-			configPath: configPath,
+			restConfig: restConfig,
 		}
 		err error
 	)
-	client.restConfig, err = getClientConfig(configPath)
-	if err != nil {
-		return nil, err
-	}
 
-	client.discoveryClient, err = discovery.NewDiscoveryClientForConfig(client.restConfig)
+	discoveryClient, err := discovery.NewDiscoveryClientForConfig(client.restConfig)
 	if err != nil {
 		return nil, err
 	}
-	client.restMapper = restmapper.NewDeferredDiscoveryRESTMapper(memory.NewMemCacheClient(client.discoveryClient))
+	// discovery is cached for the lifetime of Client, see kindToGVK
+	client.discoveryClient = memory.NewMemCacheClient(discoveryClient)
+	client.restMapper = restmapper.NewDeferredDiscoveryRESTMapper(client.discoveryClient)
 
 	// TODO: this should be suppressing this warning:
 	// `[controller-runtime] log.SetLogger(...) was never called; logs will not be displayed.`
@@ -101,6 +162,11 @@ func NewClient(_ context.Context, configPath string) (*Client, error) {
 	if err != nil {
 		return nil, err
 	}
+	client.applyMapper = client.crClient.RESTMapper()
+	client.apply = client.serverSideApply
+	client.patch = client.crPatch
+	client.listMetadata = client.crListMetadata
+	client.logs = client.clientsetLogs
 
 	client.clientset, err = k8s.NewForConfig(client.restConfig)
 	if err != nil {
@@ -144,58 +210,236 @@ func (c *Client) Deploy(ctx context.Context, envDesc *fs.EnvDescription) error {
 	return nil
 }
 
-// Apply deploys the manifest in data.
+// Apply deploys all manifests in data. Documents of multi-document YAML are
+// applied in order, empty documents are skipped and items of List are applied
+// one by one. Failure of one document doesn't stop the rest from being applied;
+// errors are reported with index of the document and identity of the object.
 func (c *Client) Apply(ctx context.Context, data *bytes.Buffer) error {
+	return forEachObject(data, func(obj *unstructured.Unstructured) error {
+		return c.applyUnstructured(ctx, obj)
+	})
+}
+
+// forEachObject calls f for every object in manifests in data: for each document
+// of multi-document YAML and for each item of List. Errors don't stop the rest
+// of objects from being processed; they are reported with index of the document
+// and identity of the object as given in the manifest.
+func forEachObject(data *bytes.Buffer, f func(obj *unstructured.Unstructured) error) error {
 	d := yaml.NewYAMLOrJSONDecoder(data, 4096)
 
-	var (
-		gvk *schema.GroupVersionKind
-		obj runtime.Object
-		ext runtime.RawExtension
-		err error
-	)
-	if err = d.Decode(&ext); err != nil {
-		return err
+	var errs []error
+	for i := 1; ; i++ {
+		var ext runtime.RawExtension
+		if err := d.Decode(&ext); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			// the rest of data cannot be decoded either
+			errs = append(errs, fmt.Errorf("document %d: %w", i, err))
+			break
+		}
+
+		raw := bytes.TrimSpace(ext.Raw)
+		if len(raw) == 0 || bytes.Equal(raw, []byte("null")) {
+			continue
+		}
+
+		if err := forEachDocumentObject(raw, f); err != nil {
+			errs = append(errs, fmt.Errorf("document %d: %w", i, err))
+		}
 	}
 
-	if _, gvk, err = unstructured.UnstructuredJSONScheme.Decode(ext.Raw, nil, obj); err != nil {
-		return err
+	return errors.Join(errs...)
+}
+
+// Kinds returns sorted kinds of objects in manifests in data, each kind once.
+// Documents which cannot be decoded are skipped.
+func Kinds(data string) []string {
+	set := map[string]struct{}{}
+	_ = forEachObject(bytes.NewBufferString(data), func(obj *unstructured.Unstructured) error {
+		set[obj.GetKind()] = struct{}{}
+		return nil
+	})
+
+	kinds := make([]string, 0, len(set))
+	for kind := range set {
+		kinds = append(kinds, kind)
 	}
+	sort.Strings(kinds)
+
+	return kinds
+}
 
-	var unstructObj unstructured.Unstructured
-	unstructObj.Object = make(map[string]interface{})
-	var blob interface{}
-	if err = json.Unmarshal(ext.Raw, &blob); err != nil {
+// forEachDocumentObject calls f for one object or for all items of a List.
+func forEachDocumentObject(raw []byte, f func(obj *unstructured.Unstructured) error) error {
+	obj, _, err := unstructured.UnstructuredJSONScheme.Decode(raw, nil, nil)
+	if err != nil {
 		return err
 	}
-	m, ok := blob.(map[string]interface{})
-	if !ok {
-		return fmt.Errorf("failed to extract map[string]interface{} from the object during apply")
+
+	switch o := obj.(type) {
+	case *unstructured.Unstructured:
+		return withIdentity(o, f)
+	case *unstructured.UnstructuredList:
+		var errs []error
+		for i := range o.Items {
+			if err := withIdentity(&o.Items[i], f); err != nil {
+				errs = append(errs, fmt.Errorf("item %d: %w", i+1, err))
+			}
+		}
+		return errors.Join(errs...)
+	default:
+		return fmt.Errorf("unexpected type of object in manifest: %T", obj)
 	}
-	unstructObj.Object = m
+}
 
-	mapper, err := c.crClient.RESTMapper().RESTMapping(gvk.GroupKind())
+// withIdentity calls f for the object, reporting errors together with its
+// identity as given in the manifest.
+func withIdentity(obj *unstructured.Unstructured, f func(obj *unstructured.Unstructured) error) error {
+	identity := objectIdentity(obj)
+
+	if err := f(obj); err != nil {
+		return fmt.Errorf("%s: %w", identity, err)
+	}
+
+	return nil
+}
+
+func (c *Client) applyUnstructured(ctx context.Context, obj *unstructured.Unstructured) error {
+	gvk := obj.GroupVersionKind()
+
+	mapper, err := c.applyMapper.RESTMapping(gvk.GroupKind(), gvk.Version)
 	if err != nil {
 		return err
 	}
 
 	// namespaced object should not have empty namespace
-	if mapper.Scope.Name() == meta.RESTScopeNameNamespace && len(unstructObj.GetNamespace()) == 0 {
-		unstructObj.SetNamespace("default")
+	if mapper.Scope.Name() == meta.RESTScopeNameNamespace && len(obj.GetNamespace()) == 0 {
+		obj.SetNamespace("default")
+	}
+
+	if c.namespaceMapper != nil {
+		if err = c.mapObjectNamespace(ctx, obj, mapper.Scope.Name() == meta.RESTScopeNameNamespace); err != nil {
+			return err
+		}
+	}
+
+	if c.trackingLabels != nil {
+		if err = c.track(ctx, obj, mapper); err != nil {
+			return err
+		}
 	}
 
+	return c.apply(ctx, obj, mapper)
+}
+
+// objectIdentity returns a short description of the object,
+// like "apps/v1 Deployment default/nginx".
+func objectIdentity(obj *unstructured.Unstructured) string {
+	name := obj.GetName()
+	if ns := obj.GetNamespace(); len(ns) > 0 {
+		name = ns + "/" + name
+	}
+
+	return fmt.Sprintf("%s %s %s", obj.GetAPIVersion(), obj.GetKind(), name)
+}
+
+func (c *Client) serverSideApply(ctx context.Context, obj *unstructured.Unstructured, _ *meta.RESTMapping) error {
 	// server side apply
 	// https://pkg.go.dev/sigs.k8s.io/controller-runtime/pkg/client#example-Client-Apply
 	return c.crClient.Patch(
 		ctx,
-		&unstructObj,
+		obj,
 		crclient.Apply,
 		crclient.ForceOwnership,
 		crclient.FieldOwner("xk6-environment"))
 }
 
-// GetN is a hopefully temporary substitute for Get
-func (c *Client) GetN(ctx context.Context, namespace string, opts *metav1.ListOptions) (int, error) {
-	podList, err := c.clientset.CoreV1().Pods(namespace).List(ctx, *opts)
-	return len(podList.Items), err
+// mapObjectNamespace moves the object into the namespace given by
+// namespaceMapper, creating the namespace if needed. Namespace objects
+// themselves are renamed.
+func (c *Client) mapObjectNamespace(ctx context.Context, obj *unstructured.Unstructured, namespaced bool) error {
+	if namespaced {
+		ns := c.namespace(obj.GetNamespace())
+		obj.SetNamespace(ns)
+		return c.ensureNamespace(ctx, ns)
+	}
+
+	if gvk := obj.GroupVersionKind(); gvk.Group == "" && gvk.Kind == "Namespace" {
+		obj.SetName(c.namespace(obj.GetName()))
+
+		l := obj.GetLabels()
+		if l == nil {
+			l = make(map[string]string)
+		}
+		for k, v := range c.namespaceMapper.NamespaceLabels() {
+			l[k] = v
+		}
+		obj.SetLabels(l)
+	}
+
+	return nil
+}
+
+// resource returns the dynamic client for the given kind. The client is scoped
+// to the namespace, "default" if it's empty, unless objects of that kind are
+// cluster-scoped. apiVersion is optional, see restMapping.
+func (c *Client) resource(apiVersion, kind, namespace string) (dynamic.ResourceInterface, error) {
+	restMapping, err := c.restMapping(apiVersion, kind)
+	if err != nil {
+		return nil, err
+	}
+
+	if restMapping.Scope.Name() == meta.RESTScopeNameNamespace {
+		return c.dynamicClient.Resource(restMapping.Resource).Namespace(c.objectNamespace(restMapping, namespace)), nil
+	}
+
+	return c.dynamicClient.Resource(restMapping.Resource), nil
+}
+
+// objectNamespace returns the namespace of objects of the mapping within
+// environment: empty for cluster-scoped kinds, otherwise the namespace,
+// "default" if it's empty, as mapped by namespaceMapper.
+func (c *Client) objectNamespace(restMapping *meta.RESTMapping, namespace string) string {
+	if restMapping.Scope.Name() != meta.RESTScopeNameNamespace {
+		return ""
+	}
+
+	if len(namespace) == 0 {
+		namespace = "default"
+	}
+
+	return c.namespace(namespace)
+}
+
+// restMapping returns REST mapping of the kind. If apiVersion is empty,
+// the preferred version of the kind is looked up with discovery; then
+// the kind can be given by name of its resource as well.
+func (c *Client) restMapping(apiVersion, kind string) (*meta.RESTMapping, error) {
+	var gvk schema.GroupVersionKind
+
+	if len(apiVersion) == 0 {
+		var err error
+		if gvk, err = c.kindToGVK(kind); err != nil {
+			return nil, err
+		}
+	} else {
+		gv, err := schema.ParseGroupVersion(apiVersion)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalid, err)
+		}
+		gvk = gv.WithKind(kind)
+	}
+
+	restMapping, err := c.restMapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	if meta.IsNoMatchError(err) {
+		// the kind may be defined by a CRD applied after discovery was cached
+		c.invalidateDiscovery()
+		restMapping, err = c.restMapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	}
+	if meta.IsNoMatchError(err) {
+		return nil, fmt.Errorf("kind %w: %w", ErrNotFound, err)
+	}
+
+	return restMapping, err
 }
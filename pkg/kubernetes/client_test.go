@@ -0,0 +1,79 @@
+package kubernetes
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/controller-runtime/pkg/envtest"
+)
+
+const testPod = `apiVersion: v1
+kind: Pod
+metadata:
+  name: nginx
+spec:
+  containers:
+  - name: nginx
+    image: nginx:1.14.2
+`
+
+// newTestClient starts a local control plane with envtest. The test is skipped
+// if envtest binaries are not available: "make test-envtest" installs them with
+// setup-envtest and runs the tests, as CI does.
+func newTestClient(t *testing.T) *Client {
+	t.Helper()
+
+	//nolint:forbidigo
+	if len(os.Getenv("KUBEBUILDER_ASSETS")) == 0 {
+		t.Skip("KUBEBUILDER_ASSETS is not set, skipping test with envtest")
+	}
+
+	te := &envtest.Environment{}
+	cfg, err := te.Start()
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		assert.NoError(t, te.Stop())
+	})
+
+	c, err := NewClientForConfig(context.Background(), cfg)
+	require.NoError(t, err)
+
+	return c
+}
+
+func Test_ApplyGetNWait(t *testing.T) {
+	t.Parallel()
+
+	c := newTestClient(t)
+	ctx := context.Background()
+
+	require.NoError(t, c.Apply(ctx, bytes.NewBufferString(testPod)))
+
+	pods, err := c.List(ctx, "", "pods", ListOptions{})
+	require.NoError(t, err)
+	assert.Len(t, pods, 1)
+
+	// only metadata of objects is fetched to count them
+	n, err := c.Count(ctx, "", "pods", ListOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+
+	// there are no controllers in envtest so pod stays pending
+	wc, err := NewWaitCondition(map[string]interface{}{
+		"kind":         "Pod",
+		"name":         "nginx",
+		"namespace":    "default",
+		"status_key":   "phase",
+		"status_value": "Pending",
+	})
+	require.NoError(t, err)
+	wc.TimeParams(100*time.Millisecond, 30*time.Second)
+	wc.Build()
+
+	assert.NoError(t, c.Wait(ctx, wc))
+}
@@ -20,6 +20,11 @@ func getConditions(ci []interface{}) []metav1.Condition {
 			if err == nil && found {
 				cond.Type = t
 			}
+
+			r, found, err := metav1u.NestedString(cm, "reason")
+			if err == nil && found {
+				cond.Reason = r
+			}
 			conditions = append(conditions, cond)
 		}
 	}
@@ -0,0 +1,63 @@
+package kubernetes
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testKubeconfig = `apiVersion: v1
+kind: Config
+clusters:
+- name: %[1]s
+  cluster:
+    server: https://%[1]s.example.com
+users:
+- name: %[1]s
+contexts:
+- name: %[1]s
+  context:
+    cluster: %[1]s
+    user: %[1]s
+current-context: %[1]s
+`
+
+func writeTestKubeconfig(t *testing.T, dir, name string) string {
+	t.Helper()
+
+	path := filepath.Join(dir, name)
+	//nolint:forbidigo
+	require.NoError(t, os.WriteFile(path, []byte(fmt.Sprintf(testKubeconfig, name)), 0o600))
+
+	return path
+}
+
+//nolint:paralleltest // KUBECONFIG is set for the whole process
+func Test_loadConfig(t *testing.T) {
+	dir := t.TempDir()
+	first := writeTestKubeconfig(t, dir, "first")
+	second := writeTestKubeconfig(t, dir, "second")
+
+	t.Setenv("KUBECONFIG", first+string(filepath.ListSeparator)+second)
+
+	// KUBECONFIG is merged: the first file wins the current context
+	restConfig, err := getClientConfig("")
+	require.NoError(t, err)
+	assert.Equal(t, "https://first.example.com", restConfig.Host)
+
+	restConfig, err = RESTConfigForContext("", "second")
+	require.NoError(t, err)
+	assert.Equal(t, "https://second.example.com", restConfig.Host)
+
+	// explicit path takes precedence over KUBECONFIG
+	restConfig, err = getClientConfig(second)
+	require.NoError(t, err)
+	assert.Equal(t, "https://second.example.com", restConfig.Host)
+
+	_, err = RESTConfigForContext(second, "first")
+	assert.Error(t, err)
+}
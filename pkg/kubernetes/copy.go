@@ -0,0 +1,275 @@
+package kubernetes
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// errSizeLimit is returned when copied files exceed CopyOptions.MaxBytes.
+var errSizeLimit = errors.New("size limit exceeded")
+
+// CopyOptions configure CopyTo and CopyFrom.
+type CopyOptions struct {
+	// Container is optional if the pod has only one container.
+	Container string
+	// MaxBytes limits the total size of copied files, if positive.
+	MaxBytes int64
+	// Timeout limits duration of the copy, if positive.
+	Timeout time.Duration
+}
+
+// CopyTo copies the local file or directory into a container of the pod,
+// like `kubectl cp`: remotePath is the path of the copy. Modes of files are
+// preserved. The container must have tar binary.
+func (c *Client) CopyTo(ctx context.Context, namespace, pod, localPath, remotePath string, opts CopyOptions) error {
+	dir, base, err := splitRemotePath(remotePath)
+	if err != nil {
+		return err
+	}
+
+	size, err := localSize(localPath)
+	if err != nil {
+		return fmt.Errorf("unable to copy %s: %w", localPath, err)
+	}
+	if opts.MaxBytes > 0 && size > opts.MaxBytes {
+		return fmt.Errorf("unable to copy %s: %w: %d bytes of files, limit is %d",
+			localPath, errSizeLimit, size, opts.MaxBytes)
+	}
+
+	pr, pw := io.Pipe()
+	go func() {
+		pw.CloseWithError(writeTar(pw, localPath, base))
+	}()
+	// unblocks writing, if tar has failed
+	defer pr.Close() //nolint:errcheck
+
+	var stderr bytes.Buffer
+	exitCode, err := c.stream(ctx, namespace, pod, []string{"tar", "-xf", "-", "-C", dir},
+		ExecOptions{Container: opts.Container, Stdin: pr, Timeout: opts.Timeout}, io.Discard, &stderr)
+	if err != nil {
+		return err
+	}
+	if exitCode != 0 {
+		return fmt.Errorf("unable to copy %s to %s in pod %s: tar exited with code %d: %s",
+			localPath, remotePath, pod, exitCode, strings.TrimSpace(stderr.String()))
+	}
+
+	return nil
+}
+
+// CopyFrom copies the file or directory from a container of the pod, like
+// `kubectl cp`: localPath is the path of the copy. Modes of files are
+// preserved; symbolic links and special files are skipped. The container
+// must have tar binary.
+func (c *Client) CopyFrom(ctx context.Context, namespace, pod, remotePath, localPath string, opts CopyOptions) error {
+	dir, base, err := splitRemotePath(remotePath)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	type result struct {
+		exitCode int
+		err      error
+	}
+
+	var (
+		stderr   bytes.Buffer
+		pr, pw   = io.Pipe()
+		resultCh = make(chan result, 1)
+	)
+
+	go func() {
+		exitCode, err := c.stream(ctx, namespace, pod, []string{"tar", "-cf", "-", "-C", dir, base},
+			ExecOptions{Container: opts.Container, Timeout: opts.Timeout}, pw, &stderr)
+		_ = pw.Close()
+		resultCh <- result{exitCode, err}
+	}()
+
+	readErr := readTar(pr, base, localPath, opts.MaxBytes)
+	if readErr != nil {
+		// there is no point in streaming the rest
+		cancel()
+	}
+	// unblocks writing of the rest, like padding of archive
+	_, _ = io.Copy(io.Discard, pr)
+
+	res := <-resultCh
+	switch {
+	case readErr != nil:
+		return fmt.Errorf("unable to copy %s from pod %s to %s: %w", remotePath, pod, localPath, readErr)
+	case res.err != nil:
+		return res.err
+	case res.exitCode != 0:
+		return fmt.Errorf("unable to copy %s from pod %s: tar exited with code %d: %s",
+			remotePath, pod, res.exitCode, strings.TrimSpace(stderr.String()))
+	}
+
+	return nil
+}
+
+// splitRemotePath returns the directory, where tar is run, and the name of
+// the file or directory within it.
+func splitRemotePath(remotePath string) (dir, base string, err error) {
+	remotePath = path.Clean(remotePath)
+	dir, base = path.Dir(remotePath), path.Base(remotePath)
+
+	if len(remotePath) == 0 || base == "/" || base == "." || base == ".." {
+		return "", "", fmt.Errorf("%w: path in container must name a file or directory: %q", ErrInvalid, remotePath)
+	}
+
+	return dir, base, nil
+}
+
+// localSize returns the total size of files at localPath.
+func localSize(localPath string) (size int64, err error) {
+	err = filepath.Walk(localPath, func(_ string, info fs.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.Mode().IsRegular() {
+			size += info.Size()
+		}
+		return nil
+	})
+
+	return size, err
+}
+
+// writeTar writes the file or directory at localPath as tar archive with base
+// as its root.
+func writeTar(w io.Writer, localPath, base string) error {
+	tw := tar.NewWriter(w)
+
+	err := filepath.Walk(localPath, func(file string, info fs.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(localPath, file)
+		if err != nil {
+			return err
+		}
+
+		var link string
+		if info.Mode()&fs.ModeSymlink != 0 {
+			if link, err = os.Readlink(file); err != nil { //nolint:forbidigo
+				return err
+			}
+		}
+
+		header, err := tar.FileInfoHeader(info, link)
+		if err != nil {
+			return err
+		}
+		header.Name = path.Join(base, filepath.ToSlash(rel))
+		if info.IsDir() {
+			header.Name += "/"
+		}
+
+		if err = tw.WriteHeader(header); err != nil {
+			return err
+		}
+
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+
+		f, err := os.Open(filepath.Clean(file)) //nolint:forbidigo
+		if err != nil {
+			return err
+		}
+		defer f.Close() //nolint:errcheck
+
+		_, err = io.Copy(tw, f)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	return tw.Close()
+}
+
+// readTar extracts tar archive with base as its root to localPath.
+func readTar(r io.Reader, base, localPath string, maxBytes int64) error {
+	var (
+		tr   = tar.NewReader(r)
+		size int64
+	)
+
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		name := path.Clean(header.Name)
+		target := localPath
+		switch {
+		case name == base:
+		case strings.HasPrefix(name, base+"/"):
+			target = filepath.Join(localPath, filepath.FromSlash(strings.TrimPrefix(name, base+"/")))
+		default:
+			return fmt.Errorf("unexpected path in archive: %q", header.Name)
+		}
+
+		mode := fs.FileMode(header.Mode).Perm() //nolint:gosec
+
+		switch header.Typeflag {
+		case tar.TypeDir:
+			if err = os.MkdirAll(target, mode|0o700); err != nil { //nolint:forbidigo
+				return err
+			}
+		case tar.TypeReg:
+			size += header.Size
+			if maxBytes > 0 && size > maxBytes {
+				return fmt.Errorf("%w: more than %d bytes of files", errSizeLimit, maxBytes)
+			}
+
+			if err = writeFile(target, mode, tr, header.Size); err != nil {
+				return err
+			}
+		default:
+			// symbolic links and special files are skipped, like in kubectl
+		}
+	}
+}
+
+func writeFile(target string, mode fs.FileMode, r io.Reader, size int64) error {
+	if err := os.MkdirAll(filepath.Dir(target), 0o750); err != nil { //nolint:forbidigo
+		return err
+	}
+
+	//nolint:forbidigo
+	f, err := os.OpenFile(filepath.Clean(target), os.O_CREATE|os.O_WRONLY|os.O_TRUNC, mode)
+	if err != nil {
+		return err
+	}
+
+	// mode of an existing file is not changed by OpenFile, and umask applies
+	if err = f.Chmod(mode); err == nil {
+		_, err = io.CopyN(f, r, size)
+	}
+	if err != nil {
+		_ = f.Close()
+		return err
+	}
+
+	return f.Close()
+}
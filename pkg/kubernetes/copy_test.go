@@ -0,0 +1,75 @@
+package kubernetes
+
+import (
+	"archive/tar"
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+//nolint:forbidigo
+func Test_tarRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	src := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(src, "sub"), 0o750))
+	require.NoError(t, os.WriteFile(filepath.Join(src, "run.sh"), []byte("#!/bin/sh\n"), 0o755)) //nolint:gosec
+	require.NoError(t, os.WriteFile(filepath.Join(src, "sub", "data.txt"), []byte("data"), 0o600))
+	require.NoError(t, os.Symlink("run.sh", filepath.Join(src, "link")))
+
+	size, err := localSize(src)
+	require.NoError(t, err)
+	assert.Equal(t, int64(14), size)
+
+	var archive bytes.Buffer
+	require.NoError(t, writeTar(&archive, src, "fixtures"))
+
+	dst := filepath.Join(t.TempDir(), "copy")
+	require.NoError(t, readTar(bytes.NewReader(archive.Bytes()), "fixtures", dst, 0))
+
+	info, err := os.Stat(filepath.Join(dst, "run.sh"))
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o755), info.Mode().Perm())
+
+	data, err := os.ReadFile(filepath.Join(dst, "sub", "data.txt"))
+	require.NoError(t, err)
+	assert.Equal(t, "data", string(data))
+
+	// symbolic links are skipped
+	_, err = os.Lstat(filepath.Join(dst, "link"))
+	assert.True(t, os.IsNotExist(err))
+
+	// size limit
+	err = readTar(bytes.NewReader(archive.Bytes()), "fixtures", filepath.Join(t.TempDir(), "copy"), 10)
+	assert.ErrorIs(t, err, errSizeLimit)
+}
+
+func Test_readTarOutsideOfBase(t *testing.T) {
+	t.Parallel()
+
+	var archive bytes.Buffer
+	tw := tar.NewWriter(&archive)
+	require.NoError(t, tw.WriteHeader(&tar.Header{Name: "base/../../escape", Mode: 0o600, Typeflag: tar.TypeReg}))
+	require.NoError(t, tw.Close())
+
+	err := readTar(&archive, "base", t.TempDir(), 0)
+	assert.ErrorContains(t, err, "unexpected path in archive")
+}
+
+func Test_splitRemotePath(t *testing.T) {
+	t.Parallel()
+
+	dir, base, err := splitRemotePath("/tmp/fixtures/")
+	require.NoError(t, err)
+	assert.Equal(t, "/tmp", dir)
+	assert.Equal(t, "fixtures", base)
+
+	for _, remotePath := range []string{"", "/", ".", ".."} {
+		_, _, err = splitRemotePath(remotePath)
+		assert.ErrorIs(t, err, ErrInvalid, remotePath)
+	}
+}
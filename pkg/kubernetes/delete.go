@@ -0,0 +1,191 @@
+package kubernetes
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/dynamic"
+)
+
+// DeleteOptions configure deletion of objects.
+type DeleteOptions struct {
+	// PropagationPolicy is the policy of garbage collection of dependents;
+	// the default policy of the kind is used if it's empty.
+	PropagationPolicy metav1.DeletionPropagation
+	// IgnoreNotFound makes deletion of missing objects succeed.
+	IgnoreNotFound bool
+	// Wait blocks until deleted objects are gone, checking every Interval
+	// up to Timeout. Defaults are the same as for WaitCondition.
+	Wait     bool
+	Interval time.Duration
+	Timeout  time.Duration
+}
+
+// deletion is a single object to be deleted.
+type deletion struct {
+	ri       dynamic.ResourceInterface
+	name     string
+	identity string
+}
+
+// Delete removes the object.
+func (c *Client) Delete(ctx context.Context, ref ObjectRef, opts DeleteOptions) error {
+	restMapping, err := c.restMapping(ref.APIVersion, ref.Kind)
+	if err != nil {
+		return err
+	}
+
+	namespace := c.objectNamespace(restMapping, ref.Namespace)
+
+	return c.deleteAll(ctx, []deletion{c.deletion(restMapping, namespace, ref.Name)}, opts)
+}
+
+// DeleteSelected removes all objects of the kind selected by labels. apiVersion
+// is optional: by default, the preferred version of kind is used. Label selector
+// is required, so that a whole kind cannot be removed by mistake.
+func (c *Client) DeleteSelected(
+	ctx context.Context, apiVersion, kind string, selector ListOptions, opts DeleteOptions,
+) error {
+	if len(selector.LabelSelector) == 0 {
+		return fmt.Errorf("%w: deletion of %s requires either name or label selector", ErrInvalid, kind)
+	}
+
+	restMapping, err := c.restMapping(apiVersion, kind)
+	if err != nil {
+		return err
+	}
+
+	items, err := c.List(ctx, apiVersion, kind, selector)
+	if err != nil {
+		return err
+	}
+
+	deletions := make([]deletion, 0, len(items))
+	for _, item := range items {
+		obj := unstructured.Unstructured{Object: item}
+		deletions = append(deletions, c.deletion(restMapping, obj.GetNamespace(), obj.GetName()))
+	}
+
+	return c.deleteAll(ctx, deletions, opts)
+}
+
+// DeleteSpec removes all objects described by manifests in data, i.e. objects
+// deployed with Apply. Multi-document YAML and List are processed as in Apply.
+func (c *Client) DeleteSpec(ctx context.Context, data *bytes.Buffer, opts DeleteOptions) error {
+	var deletions []deletion
+
+	err := forEachObject(data, func(obj *unstructured.Unstructured) error {
+		restMapping, err := c.restMapping(obj.GetAPIVersion(), obj.GetKind())
+		if err != nil {
+			return err
+		}
+
+		name, namespace := obj.GetName(), obj.GetNamespace()
+		switch {
+		case restMapping.Scope.Name() == meta.RESTScopeNameNamespace:
+			namespace = c.objectNamespace(restMapping, namespace)
+		case c.namespaceMapper != nil && restMapping.GroupVersionKind.Group == "" &&
+			restMapping.GroupVersionKind.Kind == "Namespace":
+			// Namespace objects are renamed on apply
+			name = c.namespace(name)
+		}
+
+		deletions = append(deletions, c.deletion(restMapping, namespace, name))
+		return nil
+	})
+
+	// whatever could be decoded is still deleted
+	return errors.Join(err, c.deleteAll(ctx, deletions, opts))
+}
+
+func (c *Client) deletion(restMapping *meta.RESTMapping, namespace, name string) deletion {
+	var (
+		ri       dynamic.ResourceInterface = c.dynamicClient.Resource(restMapping.Resource)
+		identity                           = name
+	)
+
+	if restMapping.Scope.Name() == meta.RESTScopeNameNamespace {
+		ri = c.dynamicClient.Resource(restMapping.Resource).Namespace(namespace)
+		identity = namespace + "/" + name
+	}
+
+	return deletion{
+		ri:       ri,
+		name:     name,
+		identity: restMapping.GroupVersionKind.Kind + " " + identity,
+	}
+}
+
+// deleteAll removes the objects and, if requested, waits until they are gone.
+func (c *Client) deleteAll(ctx context.Context, deletions []deletion, opts DeleteOptions) error {
+	deleteOpts := metav1.DeleteOptions{}
+	if len(opts.PropagationPolicy) > 0 {
+		deleteOpts.PropagationPolicy = &opts.PropagationPolicy
+	}
+
+	var (
+		errs    []error
+		deleted = make([]deletion, 0, len(deletions))
+	)
+
+	for _, d := range deletions {
+		err := d.ri.Delete(ctx, d.name, deleteOpts)
+		switch {
+		case err == nil:
+			deleted = append(deleted, d)
+		case apierrors.IsNotFound(err) && opts.IgnoreNotFound:
+		default:
+			errs = append(errs, fmt.Errorf("%s: %w", d.identity, err))
+		}
+	}
+
+	if opts.Wait && len(deleted) > 0 {
+		errs = append(errs, waitDeleted(ctx, deleted, opts))
+	}
+
+	return errors.Join(errs...)
+}
+
+// waitDeleted blocks until all the objects are gone.
+func waitDeleted(ctx context.Context, deleted []deletion, opts DeleteOptions) error {
+	interval, timeout := opts.Interval, opts.Timeout
+	if interval <= 0 {
+		interval = defaultInterval
+	}
+	if timeout <= 0 {
+		timeout = defaultTimeout
+	}
+
+	err := wait.PollUntilContextTimeout(ctx, interval, timeout, true, func(ctx context.Context) (bool, error) {
+		for len(deleted) > 0 {
+			_, err := deleted[0].ri.Get(ctx, deleted[0].name, metav1.GetOptions{})
+			if err == nil {
+				return false, nil
+			}
+			if !apierrors.IsNotFound(err) {
+				return false, err
+			}
+			deleted = deleted[1:]
+		}
+		return true, nil
+	})
+	// interruption of the test itself is not a timeout of deletion
+	if wait.Interrupted(err) && ctx.Err() == nil {
+		remaining := make([]string, 0, len(deleted))
+		for _, d := range deleted {
+			remaining = append(remaining, d.identity)
+		}
+		return fmt.Errorf("%w after %s waiting for deletion of %s", ErrTimeout, timeout, strings.Join(remaining, ", "))
+	}
+
+	return err
+}
@@ -0,0 +1,124 @@
+package kubernetes
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testDeleteSpec = `---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: first
+  labels:
+    app: test
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: second
+  namespace: other
+  labels:
+    app: test
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: third
+`
+
+func Test_Delete(t *testing.T) {
+	t.Parallel()
+
+	opts := DeleteOptions{Wait: true, Interval: 10 * time.Millisecond, Timeout: time.Second}
+
+	testCases := []struct {
+		name      string
+		delete    func(ctx context.Context, c *Client) error
+		remaining []string
+		expErr    error
+		notFound  bool
+	}{
+		{
+			name: "object",
+			delete: func(ctx context.Context, c *Client) error {
+				return c.Delete(ctx, ObjectRef{Kind: "ConfigMap", Name: "first"}, opts)
+			},
+			remaining: []string{"second", "third"},
+		},
+		{
+			name: "missing object",
+			delete: func(ctx context.Context, c *Client) error {
+				return c.Delete(ctx, ObjectRef{Kind: "ConfigMap", Name: "missing"}, opts)
+			},
+			remaining: []string{"first", "second", "third"},
+			notFound:  true,
+		},
+		{
+			name: "missing object ignored",
+			delete: func(ctx context.Context, c *Client) error {
+				return c.Delete(ctx, ObjectRef{Kind: "ConfigMap", Name: "missing"},
+					DeleteOptions{IgnoreNotFound: true})
+			},
+			remaining: []string{"first", "second", "third"},
+		},
+		{
+			name: "selected",
+			delete: func(ctx context.Context, c *Client) error {
+				return c.DeleteSelected(ctx, "", "configmaps",
+					ListOptions{AllNamespaces: true, LabelSelector: "app=test"}, opts)
+			},
+			remaining: []string{"third"},
+		},
+		{
+			name: "selector required",
+			delete: func(ctx context.Context, c *Client) error {
+				return c.DeleteSelected(ctx, "", "ConfigMap", ListOptions{AllNamespaces: true}, opts)
+			},
+			remaining: []string{"first", "second", "third"},
+			expErr:    ErrInvalid,
+		},
+		{
+			name: "spec",
+			delete: func(ctx context.Context, c *Client) error {
+				return c.DeleteSpec(ctx, bytes.NewBufferString(testDeleteSpec), opts)
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			c, err := NewFakeClient()
+			require.NoError(t, err)
+			require.NoError(t, c.Apply(ctx, bytes.NewBufferString(testDeleteSpec)))
+
+			err = testCase.delete(ctx, c)
+			switch {
+			case testCase.notFound:
+				assert.True(t, IsNotFound(err), err)
+			case testCase.expErr != nil:
+				assert.ErrorIs(t, err, testCase.expErr)
+			default:
+				assert.NoError(t, err)
+			}
+
+			items, err := c.List(ctx, "", "ConfigMap", ListOptions{AllNamespaces: true})
+			require.NoError(t, err)
+
+			names := make([]string, 0, len(items))
+			for _, item := range items {
+				names = append(names, item["metadata"].(map[string]interface{})["name"].(string)) //nolint:forcetypeassert
+			}
+			assert.ElementsMatch(t, testCase.remaining, names)
+		})
+	}
+}
@@ -0,0 +1,22 @@
+package kubernetes
+
+import (
+	"errors"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+var (
+	// ErrNotFound is returned when an object or its kind doesn't exist.
+	ErrNotFound = errors.New("not found")
+	// ErrTimeout is returned when a condition is not reached in time.
+	ErrTimeout = errors.New("timed out")
+	// ErrInvalid is returned when arguments, like a wait condition, are malformed.
+	ErrInvalid = errors.New("invalid argument")
+)
+
+// IsNotFound reports whether err means that an object or its kind doesn't
+// exist, either as ErrNotFound or as NotFound error of Kubernetes API.
+func IsNotFound(err error) bool {
+	return errors.Is(err, ErrNotFound) || apierrors.IsNotFound(err)
+}
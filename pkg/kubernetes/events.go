@@ -0,0 +1,188 @@
+package kubernetes
+
+import (
+	"context"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/dynamic"
+)
+
+// EventFilter selects events for WatchEvents.
+type EventFilter struct {
+	// Types of events, like "Warning"; events of all types are selected if empty.
+	Types []string
+	// Namespaces of events; all namespaces of environment are selected if empty.
+	Namespaces []string
+}
+
+// Event is a Kubernetes event about an object.
+type Event struct {
+	// Namespace, Kind and Name identify the object of event.
+	Namespace string
+	Kind      string
+	Name      string
+	Type      string
+	Reason    string
+	Message   string
+	Count     int32
+}
+
+// WatchEvents calls handle for every event selected by filter, until ctx is
+// done. Only events which occur after the call are handled: the watch starts
+// at the current state of events and is restarted whenever it ends.
+func (c *Client) WatchEvents(ctx context.Context, filter EventFilter, handle func(Event)) error {
+	types := make(map[string]struct{}, len(filter.Types))
+	for _, t := range filter.Types {
+		types[t] = struct{}{}
+	}
+
+	events := c.dynamicClient.Resource(corev1.SchemeGroupVersion.WithResource("events"))
+
+	w := eventWatch{
+		c:      c,
+		types:  types,
+		handle: handle,
+	}
+
+	if len(filter.Namespaces) == 0 {
+		if c.namespaceMapper != nil {
+			w.namespaces = map[string]bool{}
+		}
+		return w.start(ctx, events)
+	}
+
+	for _, ns := range filter.Namespaces {
+		if err := w.start(ctx, events.Namespace(c.namespace(ns))); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// eventWatch watches events of one or all namespaces.
+type eventWatch struct {
+	c      *Client
+	types  map[string]struct{}
+	handle func(Event)
+
+	// namespaces are known to be of environment, or not; it's nil
+	// if events of all namespaces are handled
+	namespaces map[string]bool
+}
+
+// start lists events to find the current state and watches them from it.
+func (w eventWatch) start(ctx context.Context, ri dynamic.ResourceInterface) error {
+	list, err := ri.List(ctx, metav1.ListOptions{Limit: 1})
+	if err != nil {
+		return err
+	}
+
+	go w.watch(ctx, ri, list.GetResourceVersion())
+
+	return nil
+}
+
+func (w eventWatch) watch(ctx context.Context, ri dynamic.ResourceInterface, resourceVersion string) {
+	// API server may be restarting or unreachable for a while
+	backoff := func() {
+		select {
+		case <-ctx.Done():
+		case <-time.After(defaultInterval):
+		}
+	}
+
+	relist := false
+	for ctx.Err() == nil {
+		// the state is too old to continue from, so it's found again: watching
+		// from the most recent state instead would replay all existing events
+		if relist {
+			list, err := ri.List(ctx, metav1.ListOptions{Limit: 1})
+			if err != nil {
+				backoff()
+				continue
+			}
+			resourceVersion, relist = list.GetResourceVersion(), false
+		}
+
+		watcher, err := ri.Watch(ctx, metav1.ListOptions{ResourceVersion: resourceVersion, AllowWatchBookmarks: true})
+		if err != nil {
+			backoff()
+			continue
+		}
+
+		failed := false
+		for e := range watcher.ResultChan() {
+			if e.Type == watch.Error {
+				failed = true
+				err := apierrors.FromObject(e.Object)
+				relist = apierrors.IsResourceExpired(err) || apierrors.IsGone(err)
+				break
+			}
+
+			obj, ok := e.Object.(*unstructured.Unstructured)
+			if !ok {
+				continue
+			}
+			resourceVersion = obj.GetResourceVersion()
+
+			if e.Type == watch.Added || e.Type == watch.Modified {
+				w.event(ctx, obj)
+			}
+		}
+
+		watcher.Stop()
+		if failed {
+			backoff()
+		}
+	}
+}
+
+func (w eventWatch) event(ctx context.Context, obj *unstructured.Unstructured) {
+	var event corev1.Event
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, &event); err != nil {
+		return
+	}
+
+	if _, ok := w.types[event.Type]; len(w.types) > 0 && !ok {
+		return
+	}
+
+	if w.namespaces != nil && !w.isEnvironmentNamespace(ctx, event.Namespace) {
+		return
+	}
+
+	w.handle(Event{
+		Namespace: event.Namespace,
+		Kind:      event.InvolvedObject.Kind,
+		Name:      event.InvolvedObject.Name,
+		Type:      event.Type,
+		Reason:    event.Reason,
+		Message:   event.Message,
+		Count:     event.Count,
+	})
+}
+
+// isEnvironmentNamespace checks whether the namespace was created for
+// environment; namespaces are looked up again only if they are unknown.
+func (w eventWatch) isEnvironmentNamespace(ctx context.Context, namespace string) bool {
+	if known, ok := w.namespaces[namespace]; ok {
+		return known
+	}
+
+	namespaces, err := w.c.environmentNamespaces(ctx)
+	if err != nil {
+		return false
+	}
+
+	_, known := namespaces[namespace]
+	w.namespaces[namespace] = known
+
+	return known
+}
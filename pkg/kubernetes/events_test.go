@@ -0,0 +1,144 @@
+package kubernetes
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/watch"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func Test_WatchEvents(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	c, err := NewFakeClient()
+	require.NoError(t, err)
+
+	var (
+		mu     sync.Mutex
+		events []Event
+	)
+	require.NoError(t, c.WatchEvents(ctx, EventFilter{Types: []string{corev1.EventTypeWarning}}, func(e Event) {
+		mu.Lock()
+		defer mu.Unlock()
+		events = append(events, e)
+	}))
+
+	ri := c.dynamicClient.Resource(corev1.SchemeGroupVersion.WithResource("events")).Namespace("default")
+	i := 0
+	create := func(eventType string) error {
+		i++
+		obj, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&corev1.Event{
+			TypeMeta:       metav1.TypeMeta{APIVersion: "v1", Kind: "Event"},
+			ObjectMeta:     metav1.ObjectMeta{Name: fmt.Sprintf("event-%d", i), Namespace: "default"},
+			InvolvedObject: corev1.ObjectReference{Kind: "Pod", Name: "nginx"},
+			Type:           eventType,
+			Reason:         "BackOff",
+			Message:        "Back-off restarting failed container",
+			Count:          1,
+		})
+		if err != nil {
+			return err
+		}
+		_, err = ri.Create(ctx, &unstructured.Unstructured{Object: obj}, metav1.CreateOptions{})
+		return err
+	}
+
+	// the watch is started asynchronously, so events are created until one is handled
+	assert.Eventually(t, func() bool {
+		if create(corev1.EventTypeNormal) != nil || create(corev1.EventTypeWarning) != nil {
+			return false
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		return len(events) > 0
+	}, 5*time.Second, 50*time.Millisecond)
+
+	mu.Lock()
+	defer mu.Unlock()
+	for _, e := range events {
+		assert.Equal(t, Event{
+			Namespace: "default",
+			Kind:      "Pod",
+			Name:      "nginx",
+			Type:      corev1.EventTypeWarning,
+			Reason:    "BackOff",
+			Message:   "Back-off restarting failed container",
+			Count:     1,
+		}, e)
+	}
+}
+
+func Test_eventWatchBacksOffAfterError(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var (
+		mu               sync.Mutex
+		resourceVersions []string
+		started          []time.Time
+		lists            int
+	)
+	client := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{corev1.SchemeGroupVersion.WithResource("events"): "EventList"})
+	// the watch fails right away, as the state is too old
+	client.PrependWatchReactor("events", func(action k8stesting.Action) (bool, watch.Interface, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		resourceVersions = append(resourceVersions,
+			action.(k8stesting.WatchActionImpl).WatchRestrictions.ResourceVersion) //nolint:forcetypeassert
+		started = append(started, time.Now())
+
+		watcher := watch.NewFakeWithChanSize(1, false)
+		watcher.Error(&metav1.Status{Status: metav1.StatusFailure, Reason: metav1.StatusReasonExpired, Code: 410})
+		return true, watcher, nil
+	})
+	// and the state can't be listed again right away
+	client.PrependReactor("list", "events", func(k8stesting.Action) (bool, runtime.Object, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		lists++
+		if lists < 2 {
+			return true, nil, errors.New("unavailable")
+		}
+
+		list := &unstructured.UnstructuredList{}
+		list.SetResourceVersion("7")
+		return true, list, nil
+	})
+
+	ri := client.Resource(corev1.SchemeGroupVersion.WithResource("events")).Namespace("default")
+	go eventWatch{handle: func(Event) {}}.watch(ctx, ri, "5")
+
+	assert.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(started) >= 2
+	}, 4*defaultInterval, 50*time.Millisecond)
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.GreaterOrEqual(t, len(started), 2)
+	// after the watch and after the failed list
+	assert.GreaterOrEqual(t, started[1].Sub(started[0]), 2*defaultInterval)
+	// never from the most recent state, which would replay existing events
+	assert.Equal(t, []string{"5", "7"}, resourceVersions[:2])
+}
@@ -0,0 +1,134 @@
+package kubernetes
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/httpstream"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/remotecommand"
+	"k8s.io/client-go/util/exec"
+)
+
+// errNoServer is returned by operations which stream to the API server,
+// like Exec, when Client has no access to it, e.g. the fake Client.
+var errNoServer = errors.New("streaming requires access to Kubernetes API server")
+
+// ExecOptions configure Exec.
+type ExecOptions struct {
+	// Container is optional if the pod has only one container.
+	Container string
+	// Stdin is passed to the command, if it's not nil.
+	Stdin io.Reader
+	// Timeout limits duration of the command, if positive.
+	Timeout time.Duration
+}
+
+// ExecResult is the output of the command run with Exec.
+type ExecResult struct {
+	Stdout   string
+	Stderr   string
+	ExitCode int
+}
+
+// Exec runs the command in a container of the pod, like `kubectl exec`.
+// Non-zero exit code of the command is not an error: it's returned in
+// the result, together with the output.
+func (c *Client) Exec(
+	ctx context.Context, namespace, pod string, command []string, opts ExecOptions,
+) (*ExecResult, error) {
+	var stdout, stderr bytes.Buffer
+
+	exitCode, err := c.stream(ctx, namespace, pod, command, opts, &stdout, &stderr)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ExecResult{Stdout: stdout.String(), Stderr: stderr.String(), ExitCode: exitCode}, nil
+}
+
+// stream runs the command in a container of the pod, streaming its output
+// to stdout and stderr, and returns its exit code. Error is returned only if
+// the command cannot be run or doesn't finish in time.
+func (c *Client) stream(
+	ctx context.Context, namespace, pod string, command []string, opts ExecOptions, stdout, stderr io.Writer,
+) (int, error) {
+	if len(command) == 0 {
+		return 0, fmt.Errorf("%w: command to exec in pod %s is empty", ErrInvalid, pod)
+	}
+
+	if c.restConfig == nil {
+		return 0, errNoServer
+	}
+
+	if len(namespace) == 0 {
+		namespace = "default"
+	}
+
+	req := c.clientset.CoreV1().RESTClient().Post().
+		Resource("pods").
+		Namespace(c.namespace(namespace)).
+		Name(pod).
+		SubResource("exec").
+		VersionedParams(&corev1.PodExecOptions{
+			Container: opts.Container,
+			Command:   command,
+			Stdin:     opts.Stdin != nil,
+			Stdout:    true,
+			Stderr:    true,
+		}, scheme.ParameterCodec)
+
+	executor, err := c.executor(req.URL())
+	if err != nil {
+		return 0, err
+	}
+
+	streamCtx := ctx
+	if opts.Timeout > 0 {
+		var cancel context.CancelFunc
+		streamCtx, cancel = context.WithTimeout(ctx, opts.Timeout)
+		defer cancel()
+	}
+
+	err = executor.StreamWithContext(streamCtx, remotecommand.StreamOptions{
+		Stdin:  opts.Stdin,
+		Stdout: stdout,
+		Stderr: stderr,
+	})
+
+	var exitErr exec.ExitError
+	switch {
+	case err == nil:
+		return 0, nil
+	case errors.As(err, &exitErr) && exitErr.Exited():
+		return exitErr.ExitStatus(), nil
+	// interruption of the test itself is not a timeout of the command
+	case streamCtx.Err() != nil && ctx.Err() == nil:
+		return 0, fmt.Errorf("%w after %s running %q in pod %s", ErrTimeout, opts.Timeout, command[0], pod)
+	default:
+		return 0, fmt.Errorf("unable to exec %q in pod %s: %w", command[0], pod, err)
+	}
+}
+
+// executor streams over WebSocket, falling back to SPDY if the API server
+// doesn't support it, like kubectl does.
+func (c *Client) executor(url *url.URL) (remotecommand.Executor, error) {
+	spdyExecutor, err := remotecommand.NewSPDYExecutor(c.restConfig, http.MethodPost, url)
+	if err != nil {
+		return nil, err
+	}
+
+	websocketExecutor, err := remotecommand.NewWebSocketExecutor(c.restConfig, http.MethodGet, url.String())
+	if err != nil {
+		return nil, err
+	}
+
+	return remotecommand.NewFallbackExecutor(websocketExecutor, spdyExecutor, httpstream.IsUpgradeFailure)
+}
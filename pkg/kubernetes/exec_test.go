@@ -0,0 +1,23 @@
+package kubernetes
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ExecErrors(t *testing.T) {
+	t.Parallel()
+
+	c, err := NewFakeClient()
+	require.NoError(t, err)
+
+	_, err = c.Exec(context.Background(), "default", "nginx", nil, ExecOptions{})
+	assert.ErrorIs(t, err, ErrInvalid)
+
+	// there is no API server to stream to
+	_, err = c.Exec(context.Background(), "default", "nginx", []string{"ls"}, ExecOptions{})
+	assert.ErrorIs(t, err, errNoServer)
+}
@@ -0,0 +1,191 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/discovery/cached/memory"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/restmapper"
+)
+
+func fakeAPIResource(name, kind string, namespaced bool) metav1.APIResource {
+	return metav1.APIResource{
+		Name:       name,
+		Namespaced: namespaced,
+		Kind:       kind,
+		Verbs:      metav1.Verbs{"create", "delete", "get", "list", "patch", "update", "watch"},
+	}
+}
+
+// fakeResources returns the built-in kinds known to the fake Client.
+func fakeResources() []*metav1.APIResourceList {
+	return []*metav1.APIResourceList{
+		{
+			GroupVersion: "v1",
+			APIResources: []metav1.APIResource{
+				fakeAPIResource("pods", "Pod", true),
+				fakeAPIResource("services", "Service", true),
+				fakeAPIResource("endpoints", "Endpoints", true),
+				fakeAPIResource("configmaps", "ConfigMap", true),
+				fakeAPIResource("secrets", "Secret", true),
+				fakeAPIResource("serviceaccounts", "ServiceAccount", true),
+				fakeAPIResource("persistentvolumeclaims", "PersistentVolumeClaim", true),
+				fakeAPIResource("events", "Event", true),
+				fakeAPIResource("namespaces", "Namespace", false),
+				fakeAPIResource("nodes", "Node", false),
+				fakeAPIResource("persistentvolumes", "PersistentVolume", false),
+			},
+		},
+		{
+			GroupVersion: "apps/v1",
+			APIResources: []metav1.APIResource{
+				fakeAPIResource("deployments", "Deployment", true),
+				fakeAPIResource("statefulsets", "StatefulSet", true),
+				fakeAPIResource("daemonsets", "DaemonSet", true),
+				fakeAPIResource("replicasets", "ReplicaSet", true),
+			},
+		},
+		{
+			GroupVersion: "batch/v1",
+			APIResources: []metav1.APIResource{
+				fakeAPIResource("jobs", "Job", true),
+				fakeAPIResource("cronjobs", "CronJob", true),
+			},
+		},
+		{
+			GroupVersion: "networking.k8s.io/v1",
+			APIResources: []metav1.APIResource{
+				fakeAPIResource("ingresses", "Ingress", true),
+				fakeAPIResource("networkpolicies", "NetworkPolicy", true),
+			},
+		},
+		{
+			GroupVersion: "rbac.authorization.k8s.io/v1",
+			APIResources: []metav1.APIResource{
+				fakeAPIResource("roles", "Role", true),
+				fakeAPIResource("rolebindings", "RoleBinding", true),
+				fakeAPIResource("clusterroles", "ClusterRole", false),
+				fakeAPIResource("clusterrolebindings", "ClusterRoleBinding", false),
+			},
+		},
+	}
+}
+
+// NewFakeClient constructs a Client which keeps all objects in memory,
+// without access to any cluster. Only the built-in kinds are supported.
+// Server side apply is approximated by creating the object or, if it
+// already exists, by merge patch; see also fakePatch.
+//
+// Objects are kept by the fake dynamic client only: the fake clientset
+// has separate storage, so Client reads and creates objects with the
+// dynamic client, even those of typed API, like pods or namespaces.
+func NewFakeClient() (*Client, error) {
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		return nil, err
+	}
+
+	clientset := k8sfake.NewSimpleClientset()
+	discoveryClient, ok := clientset.Discovery().(*fakediscovery.FakeDiscovery)
+	if !ok {
+		return nil, fmt.Errorf("unexpected type of fake discovery client: %T", clientset.Discovery())
+	}
+	discoveryClient.Resources = fakeResources()
+
+	cachedDiscovery := memory.NewMemCacheClient(discoveryClient)
+	mapper := restmapper.NewDeferredDiscoveryRESTMapper(cachedDiscovery)
+
+	client := &Client{
+		discoveryClient: cachedDiscovery,
+		clientset:       clientset,
+		dynamicClient:   dynamicfake.NewSimpleDynamicClient(scheme),
+		restMapper:      mapper,
+		applyMapper:     mapper,
+	}
+	client.apply = client.approximateApply
+	client.patch = client.fakePatch
+	client.listMetadata = client.fakeListMetadata
+	client.logs = client.fakeLogs
+
+	return client, nil
+}
+
+func (c *Client) approximateApply(ctx context.Context, obj *unstructured.Unstructured, mapping *meta.RESTMapping) error {
+	ri := c.dynamicClient.Resource(mapping.Resource).Namespace(obj.GetNamespace())
+
+	_, err := ri.Get(ctx, obj.GetName(), metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		_, err = ri.Create(ctx, obj, metav1.CreateOptions{FieldManager: "xk6-environment"})
+		return err
+	}
+	if err != nil {
+		return err
+	}
+
+	data, err := obj.MarshalJSON()
+	if err != nil {
+		return err
+	}
+
+	_, err = ri.Patch(ctx, obj.GetName(), types.MergePatchType, data, metav1.PatchOptions{FieldManager: "xk6-environment"})
+	return err
+}
+
+// fakeListMetadata lists objects with dynamic client, which has no
+// metadata-only lists, and returns their metadata.
+func (c *Client) fakeListMetadata(
+	ctx context.Context, mapping *meta.RESTMapping, namespace string, opts metav1.ListOptions,
+) (*metav1.PartialObjectMetadataList, error) {
+	list, err := c.dynamicClient.Resource(mapping.Resource).Namespace(namespace).List(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	metadata := &metav1.PartialObjectMetadataList{Items: make([]metav1.PartialObjectMetadata, 0, len(list.Items))}
+	for _, item := range list.Items {
+		metadata.Items = append(metadata.Items, metav1.PartialObjectMetadata{
+			ObjectMeta: metav1.ObjectMeta{Name: item.GetName(), Namespace: item.GetNamespace(), Labels: item.GetLabels()},
+		})
+	}
+
+	return metadata, nil
+}
+
+// fakeLogs returns the same logs for any container of an existing pod,
+// as the fake clientset does, which knows no pods. Namespace is mapped already.
+func (c *Client) fakeLogs(ctx context.Context, namespace, pod string, opts *corev1.PodLogOptions) ([]byte, error) {
+	u, err := c.dynamicClient.Resource(corev1.SchemeGroupVersion.WithResource("pods")).
+		Namespace(namespace).
+		Get(ctx, pod, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	var p corev1.Pod
+	if err = runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, &p); err != nil {
+		return nil, err
+	}
+
+	if len(opts.Container) > 0 {
+		found := false
+		for _, container := range p.Spec.Containers {
+			found = found || container.Name == opts.Container
+		}
+		if !found {
+			return nil, apierrors.NewBadRequest(fmt.Sprintf("container %s is not valid for pod %s", opts.Container, pod))
+		}
+	}
+
+	return c.clientsetLogs(ctx, namespace, pod, opts)
+}
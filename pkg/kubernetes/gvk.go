@@ -1,15 +1,57 @@
 package kubernetes
 
 import (
+	"errors"
 	"fmt"
 	"strings"
 
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/discovery"
 )
 
-func kindToGVK(kind string, discoveryClient *discovery.DiscoveryClient) (schema.GroupVersionKind, error) {
-	apiResources, err := discoveryClient.ServerPreferredResources()
+var errKindNotFound = fmt.Errorf("kind %w", ErrNotFound)
+
+// kindToGVK looks up the given kind, or the name of its resource like "pods",
+// with the cached discovery of Client. If neither is found, e.g. when the kind
+// is defined by a CRD applied after the cache was filled, the cache is
+// invalidated and the lookup is repeated once.
+func (c *Client) kindToGVK(kind string) (schema.GroupVersionKind, error) {
+	gvk, err := c.lookupGVK(kind)
+	if errors.Is(err, errKindNotFound) {
+		c.invalidateDiscovery()
+		gvk, err = c.lookupGVK(kind)
+	}
+
+	return gvk, err
+}
+
+func (c *Client) lookupGVK(kind string) (schema.GroupVersionKind, error) {
+	gvk, err := kindToGVK(kind, c.discoveryClient)
+	if !errors.Is(err, errKindNotFound) {
+		return gvk, err
+	}
+
+	// kind can also be given by name of its resource
+	if resourceGVK, resourceErr := c.restMapper.KindFor(schema.GroupVersionResource{Resource: kind}); resourceErr == nil {
+		return resourceGVK, nil
+	}
+
+	return gvk, err
+}
+
+// invalidateDiscovery drops cached discovery, together with REST mappings
+// built from it.
+func (c *Client) invalidateDiscovery() {
+	c.discoveryClient.Invalidate()
+
+	if m, ok := c.restMapper.(meta.ResettableRESTMapper); ok {
+		m.Reset()
+	}
+}
+
+func kindToGVK(kind string, discoveryClient discovery.DiscoveryInterface) (schema.GroupVersionKind, error) {
+	apiResources, err := discovery.ServerPreferredResources(discoveryClient)
 	if err != nil {
 		return schema.GroupVersionKind{}, err
 	}
@@ -23,7 +65,7 @@ func kindToGVK(kind string, discoveryClient *discovery.DiscoveryClient) (schema.
 			}
 		}
 	}
-	return schema.GroupVersionKind{}, fmt.Errorf("kind not found")
+	return schema.GroupVersionKind{}, fmt.Errorf("%w: %s", errKindNotFound, kind)
 }
 
 func splitGV(groupVersion string) (string, string) {
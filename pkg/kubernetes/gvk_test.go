@@ -0,0 +1,91 @@
+package kubernetes
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+)
+
+func Test_kindToGVKCached(t *testing.T) {
+	t.Parallel()
+
+	c, err := NewFakeClient()
+	require.NoError(t, err)
+
+	fake, ok := c.clientset.Discovery().(*fakediscovery.FakeDiscovery)
+	require.True(t, ok)
+
+	gvk, err := c.kindToGVK("Deployment")
+	require.NoError(t, err)
+	assert.Equal(t, "apps", gvk.Group)
+
+	// the next lookup is served from cache
+	calls := len(fake.Actions())
+	require.NotZero(t, calls)
+	_, err = c.kindToGVK("Pod")
+	require.NoError(t, err)
+	assert.Len(t, fake.Actions(), calls)
+
+	// a kind which appears later, e.g. with a new CRD, is found after invalidation
+	fake.Resources = append(fake.Resources, &metav1.APIResourceList{
+		GroupVersion: "example.com/v1",
+		APIResources: []metav1.APIResource{{Name: "widgets", Kind: "Widget", Namespaced: true, Verbs: metav1.Verbs{"get"}}},
+	})
+	gvk, err = c.kindToGVK("Widget")
+	require.NoError(t, err)
+	assert.Equal(t, "example.com", gvk.Group)
+
+	_, err = c.kindToGVK("Unknown")
+	assert.ErrorIs(t, err, errKindNotFound)
+}
+
+func Test_restMappingByResource(t *testing.T) {
+	t.Parallel()
+
+	c, err := NewFakeClient()
+	require.NoError(t, err)
+
+	fake, ok := c.clientset.Discovery().(*fakediscovery.FakeDiscovery)
+	require.True(t, ok)
+
+	restMapping, err := c.restMapping("", "deployments")
+	require.NoError(t, err)
+	assert.Equal(t, "Deployment", restMapping.GroupVersionKind.Kind)
+
+	// kinds given by name of resource are found in cache, without invalidation
+	calls := len(fake.Actions())
+	require.NotZero(t, calls)
+	for _, kind := range []string{"pods", "configmaps", "deployments", "Deployment"} {
+		_, err = c.restMapping("", kind)
+		require.NoError(t, err)
+	}
+	assert.Len(t, fake.Actions(), calls)
+
+	_, err = c.restMapping("", "unknowns")
+	assert.True(t, IsNotFound(err))
+}
+
+func Test_restMappingInvalidated(t *testing.T) {
+	t.Parallel()
+
+	c, err := NewFakeClient()
+	require.NoError(t, err)
+
+	fake, ok := c.clientset.Discovery().(*fakediscovery.FakeDiscovery)
+	require.True(t, ok)
+
+	_, err = c.restMapping("example.com/v1", "Widget")
+	assert.True(t, IsNotFound(err))
+
+	// a kind with explicit version which appears later is found after invalidation
+	fake.Resources = append(fake.Resources, &metav1.APIResourceList{
+		GroupVersion: "example.com/v1",
+		APIResources: []metav1.APIResource{{Name: "widgets", Kind: "Widget", Namespaced: true, Verbs: metav1.Verbs{"get"}}},
+	})
+	restMapping, err := c.restMapping("example.com/v1", "Widget")
+	require.NoError(t, err)
+	assert.Equal(t, "widgets", restMapping.Resource.Resource)
+}
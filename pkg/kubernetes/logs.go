@@ -0,0 +1,91 @@
+package kubernetes
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// LogOptions configure Logs.
+type LogOptions struct {
+	// Container is optional if the pod has only one container.
+	Container string
+	// TailLines is the number of lines from the end of logs, if positive.
+	TailLines int64
+	// SinceSeconds limits logs to the given number of last seconds, if positive.
+	SinceSeconds int64
+	// Previous returns logs of the previous instance of the container,
+	// e.g. the one which crashed.
+	Previous bool
+}
+
+// Logs returns logs of a container of the pod, like `kubectl logs`.
+func (c *Client) Logs(ctx context.Context, namespace, pod string, opts LogOptions) (string, error) {
+	if len(namespace) == 0 {
+		namespace = "default"
+	}
+
+	return c.podLogs(ctx, c.namespace(namespace), pod, opts)
+}
+
+// SelectedLogs returns logs of pods selected by labels, by name of pod.
+func (c *Client) SelectedLogs(
+	ctx context.Context, namespace, selector string, opts LogOptions,
+) (map[string]string, error) {
+	if len(selector) == 0 {
+		return nil, fmt.Errorf("%w: logs require either name of pod or label selector", ErrInvalid)
+	}
+
+	pods, err := c.List(ctx, "v1", "Pod", ListOptions{Namespace: namespace, LabelSelector: selector})
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		logs = make(map[string]string, len(pods))
+		errs []error
+	)
+
+	for _, item := range pods {
+		pod := unstructured.Unstructured{Object: item}
+
+		// namespace of listed pods is mapped already
+		text, err := c.podLogs(ctx, pod.GetNamespace(), pod.GetName(), opts)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		logs[pod.GetName()] = text
+	}
+
+	return logs, errors.Join(errs...)
+}
+
+func (c *Client) podLogs(ctx context.Context, namespace, pod string, opts LogOptions) (string, error) {
+	logOpts := &corev1.PodLogOptions{
+		Container: opts.Container,
+		Previous:  opts.Previous,
+	}
+	if opts.TailLines > 0 {
+		logOpts.TailLines = &opts.TailLines
+	}
+	if opts.SinceSeconds > 0 {
+		logOpts.SinceSeconds = &opts.SinceSeconds
+	}
+
+	data, err := c.logs(ctx, namespace, pod, logOpts)
+	if err != nil {
+		return "", fmt.Errorf("unable to get logs of pod %s: %w", pod, err)
+	}
+
+	return string(data), nil
+}
+
+func (c *Client) clientsetLogs(
+	ctx context.Context, namespace, pod string, opts *corev1.PodLogOptions,
+) ([]byte, error) {
+	return c.clientset.CoreV1().Pods(namespace).GetLogs(pod, opts).DoRaw(ctx)
+}
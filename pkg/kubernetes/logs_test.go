@@ -0,0 +1,55 @@
+package kubernetes
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testLogsSpec = `apiVersion: v1
+kind: List
+items:
+- apiVersion: v1
+  kind: Pod
+  metadata:
+    name: first
+    labels:
+      app: test
+- apiVersion: v1
+  kind: Pod
+  metadata:
+    name: second
+    labels:
+      app: test
+- apiVersion: v1
+  kind: Pod
+  metadata:
+    name: other
+`
+
+func Test_Logs(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	c, err := NewFakeClient()
+	require.NoError(t, err)
+	require.NoError(t, c.Apply(ctx, bytes.NewBufferString(testLogsSpec)))
+
+	// the fake clientset returns the same logs for any existing pod
+	text, err := c.Logs(ctx, "", "first", LogOptions{TailLines: 10})
+	require.NoError(t, err)
+	assert.Equal(t, "fake logs", text)
+
+	_, err = c.Logs(ctx, "", "missing", LogOptions{})
+	assert.True(t, IsNotFound(err))
+
+	logs, err := c.SelectedLogs(ctx, "", "app=test", LogOptions{SinceSeconds: 60, Previous: true})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"first": "fake logs", "second": "fake logs"}, logs)
+
+	_, err = c.SelectedLogs(ctx, "", "", LogOptions{})
+	assert.ErrorIs(t, err, ErrInvalid)
+}
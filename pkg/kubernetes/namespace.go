@@ -0,0 +1,136 @@
+package kubernetes
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// NamespaceMapper translates namespaces used in manifests and in
+// user's queries into namespaces of the cluster. It is used when
+// an environment is isolated by namespaces within existing cluster.
+type NamespaceMapper interface {
+	// MapNamespace returns the namespace in the cluster which
+	// corresponds to the given namespace.
+	MapNamespace(namespace string) string
+	// NamespaceLabels returns labels to set on namespaces created by Client.
+	NamespaceLabels() map[string]string
+}
+
+// SetNamespaceMapper configures Client to translate namespaces
+// with the given NamespaceMapper.
+func (c *Client) SetNamespaceMapper(m NamespaceMapper) {
+	c.namespaceMapper = m
+	c.namespaces = make(map[string]struct{})
+}
+
+func (c *Client) namespace(ns string) string {
+	if c.namespaceMapper == nil {
+		return ns
+	}
+
+	return c.namespaceMapper.MapNamespace(ns)
+}
+
+// ensureNamespace creates the namespace, if it wasn't created by Client yet.
+func (c *Client) ensureNamespace(ctx context.Context, name string) error {
+	if _, ok := c.namespaces[name]; ok {
+		return nil
+	}
+
+	if err := c.EnsureNamespace(ctx, name, c.namespaceMapper.NamespaceLabels()); err != nil {
+		return err
+	}
+
+	c.namespaces[name] = struct{}{}
+	return nil
+}
+
+// EnsureNamespace creates the namespace with given labels, unless it already exists.
+func (c *Client) EnsureNamespace(ctx context.Context, name string, l map[string]string) error {
+	err := c.createTyped(ctx, "v1", "Namespace", "", &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   name,
+			Labels: l,
+		},
+	})
+	if apierrors.IsAlreadyExists(err) {
+		return nil
+	}
+
+	return err
+}
+
+// DeleteNamespace removes the namespace together with everything in it.
+func (c *Client) DeleteNamespace(ctx context.Context, name string) error {
+	ri, err := c.resource("v1", "Namespace", "")
+	if err != nil {
+		return err
+	}
+
+	err = ri.Delete(ctx, name, metav1.DeleteOptions{})
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+
+	return err
+}
+
+// DeleteNamespaces removes all namespaces that have the given labels,
+// together with everything in them.
+func (c *Client) DeleteNamespaces(ctx context.Context, l map[string]string) error {
+	names, err := c.labelledNamespaces(ctx, l)
+	if err != nil {
+		return err
+	}
+
+	for name := range names {
+		if err = c.DeleteNamespace(ctx, name); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// environmentNamespaces returns names of namespaces created for
+// environment by NamespaceMapper.
+func (c *Client) environmentNamespaces(ctx context.Context) (map[string]struct{}, error) {
+	return c.labelledNamespaces(ctx, c.namespaceMapper.NamespaceLabels())
+}
+
+// labelledNamespaces returns names of namespaces that have the given labels.
+func (c *Client) labelledNamespaces(ctx context.Context, l map[string]string) (map[string]struct{}, error) {
+	namespaces, err := c.List(ctx, "v1", "Namespace", ListOptions{
+		LabelSelector: labels.SelectorFromSet(l).String(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	names := make(map[string]struct{}, len(namespaces))
+	for _, ns := range namespaces {
+		names[(&unstructured.Unstructured{Object: ns}).GetName()] = struct{}{}
+	}
+
+	return names, nil
+}
+
+// NamespacePhase returns the phase of the namespace. If the namespace
+// doesn't exist, found is false.
+func (c *Client) NamespacePhase(ctx context.Context, name string) (phase corev1.NamespacePhase, found bool, err error) {
+	var ns corev1.Namespace
+	err = c.getTyped(ctx, ObjectRef{APIVersion: "v1", Kind: "Namespace", Name: name}, &ns)
+	if apierrors.IsNotFound(err) {
+		return "", false, nil
+	}
+	if err != nil {
+		return "", false, err
+	}
+
+	return ns.Status.Phase, true, nil
+}
@@ -0,0 +1,85 @@
+package kubernetes
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func Test_Namespaces(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	c, err := NewFakeClient()
+	require.NoError(t, err)
+
+	l := map[string]string{"xk6-environment/name": "test"}
+	require.NoError(t, c.EnsureNamespace(ctx, "test-a", l))
+	require.NoError(t, c.EnsureNamespace(ctx, "test-a", l))
+	require.NoError(t, c.Apply(ctx, bytes.NewBufferString(`apiVersion: v1
+kind: Namespace
+metadata:
+  name: other
+`)))
+
+	// namespaces created by Client and applied ones are the same objects
+	namespaces, err := c.List(ctx, "v1", "Namespace", ListOptions{})
+	require.NoError(t, err)
+	assert.Len(t, namespaces, 2)
+
+	_, found, err := c.NamespacePhase(ctx, "other")
+	require.NoError(t, err)
+	assert.True(t, found)
+
+	require.NoError(t, c.DeleteNamespaces(ctx, l))
+	_, found, err = c.NamespacePhase(ctx, "test-a")
+	require.NoError(t, err)
+	assert.False(t, found)
+
+	require.NoError(t, c.DeleteNamespace(ctx, "other"))
+	require.NoError(t, c.DeleteNamespace(ctx, "other"))
+	n, err := c.Count(ctx, "v1", "Namespace", ListOptions{})
+	require.NoError(t, err)
+	assert.Zero(t, n)
+}
+
+func Test_WaitEvent(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	c, err := NewFakeClient()
+	require.NoError(t, err)
+
+	wc, err := NewWaitCondition(map[string]interface{}{
+		"kind": "Pod", "name": "nginx", "namespace": "default", "reason": "Started",
+	})
+	require.NoError(t, err)
+	wc.TimeParams(10*time.Millisecond, 100*time.Millisecond)
+	wc.Build()
+
+	// event of another object
+	require.NoError(t, c.Apply(ctx, bytes.NewBufferString(`apiVersion: v1
+kind: Event
+metadata:
+  name: other.started
+  namespace: default
+involvedObject:
+  kind: Pod
+  name: other
+reason: Started
+`)))
+	assert.ErrorIs(t, c.Wait(ctx, wc), ErrTimeout)
+
+	require.NoError(t, c.createTyped(ctx, "v1", "Event", "default", &corev1.Event{
+		ObjectMeta:     metav1.ObjectMeta{Name: "nginx.started", Namespace: "default"},
+		InvolvedObject: corev1.ObjectReference{Kind: "Pod", Name: "nginx"},
+		Reason:         "Started",
+	}))
+	assert.NoError(t, c.Wait(ctx, wc))
+}
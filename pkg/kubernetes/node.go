@@ -0,0 +1,20 @@
+package kubernetes
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+// CreateNode registers the node, unless it already exists.
+// Unlike apply, creation of a node keeps its .status, so this
+// can be used to register simulated nodes with given capacity.
+func (c *Client) CreateNode(ctx context.Context, node *corev1.Node) error {
+	err := c.createTyped(ctx, "v1", "Node", "", node)
+	if apierrors.IsAlreadyExists(err) {
+		return nil
+	}
+
+	return err
+}
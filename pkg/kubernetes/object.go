@@ -0,0 +1,198 @@
+package kubernetes
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	crclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ObjectRef identifies an object within environment.
+type ObjectRef struct {
+	// APIVersion is optional: by default, the preferred version of Kind is used.
+	APIVersion string
+	Kind       string
+	Name       string
+	// Namespace is ignored for cluster-scoped kinds; it's "default" if empty.
+	Namespace string
+}
+
+// Get returns the object as unstructured content, i.e. as it is
+// represented in JSON.
+func (c *Client) Get(ctx context.Context, ref ObjectRef) (map[string]interface{}, error) {
+	ri, err := c.resource(ref.APIVersion, ref.Kind, ref.Namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	obj, err := ri.Get(ctx, ref.Name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	return obj.Object, nil
+}
+
+// getTyped gets the object with the dynamic client, like Get, and converts it
+// into obj of its typed API.
+func (c *Client) getTyped(ctx context.Context, ref ObjectRef, obj interface{}) error {
+	u, err := c.Get(ctx, ref)
+	if err != nil {
+		return err
+	}
+
+	return runtime.DefaultUnstructuredConverter.FromUnstructured(u, obj)
+}
+
+// createTyped creates obj of the typed API of the kind with the dynamic client.
+// Typed objects are created and read with the dynamic client, like all other
+// objects, so that they are seen the same by the fake Client.
+func (c *Client) createTyped(ctx context.Context, apiVersion, kind, namespace string, obj interface{}) error {
+	ri, err := c.resource(apiVersion, kind, namespace)
+	if err != nil {
+		return err
+	}
+
+	u, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return err
+	}
+
+	content := &unstructured.Unstructured{Object: u}
+	content.SetAPIVersion(apiVersion)
+	content.SetKind(kind)
+
+	_, err = ri.Create(ctx, content, metav1.CreateOptions{FieldManager: "xk6-environment"})
+	return err
+}
+
+// ListOptions select objects to be listed.
+type ListOptions struct {
+	// Namespace is ignored for cluster-scoped kinds; it's "default" if empty.
+	Namespace string
+	// AllNamespaces lists objects in all namespaces of environment.
+	AllNamespaces bool
+	LabelSelector string
+	FieldSelector string
+	// Limit is the maximum number of objects to return, if positive.
+	Limit int64
+}
+
+// List returns objects of the given kind, as unstructured content. apiVersion
+// is optional: by default, the preferred version of kind is used.
+func (c *Client) List(ctx context.Context, apiVersion, kind string, opts ListOptions) ([]map[string]interface{}, error) {
+	scope, err := c.listScope(ctx, apiVersion, kind, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	list, err := c.dynamicClient.Resource(scope.restMapping.Resource).Namespace(scope.namespace).List(ctx, scope.opts)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]map[string]interface{}, 0, len(list.Items))
+	for _, item := range list.Items {
+		if !scope.selects(item.GetNamespace()) {
+			continue
+		}
+		if opts.Limit > 0 && int64(len(items)) >= opts.Limit {
+			break
+		}
+		items = append(items, item.Object)
+	}
+
+	return items, nil
+}
+
+// Count returns the number of objects of the given kind, selected like
+// in List. Only metadata of objects is fetched.
+func (c *Client) Count(ctx context.Context, apiVersion, kind string, opts ListOptions) (int, error) {
+	scope, err := c.listScope(ctx, apiVersion, kind, opts)
+	if err != nil {
+		return 0, err
+	}
+
+	list, err := c.listMetadata(ctx, scope.restMapping, scope.namespace, scope.opts)
+	if err != nil {
+		return 0, err
+	}
+
+	n := 0
+	for _, item := range list.Items {
+		if scope.selects(item.Namespace) {
+			n++
+		}
+	}
+	if opts.Limit > 0 && int64(n) > opts.Limit {
+		n = int(opts.Limit)
+	}
+
+	return n, nil
+}
+
+// listScope is what is listed by List or Count.
+type listScope struct {
+	restMapping *meta.RESTMapping
+	// namespace is empty if objects of all namespaces are listed
+	namespace string
+	opts      metav1.ListOptions
+	// namespaces of environment, if they are only a part of the cluster
+	namespaces map[string]struct{}
+}
+
+func (c *Client) listScope(ctx context.Context, apiVersion, kind string, opts ListOptions) (*listScope, error) {
+	restMapping, err := c.restMapping(apiVersion, kind)
+	if err != nil {
+		return nil, err
+	}
+
+	scope := &listScope{
+		restMapping: restMapping,
+		opts: metav1.ListOptions{
+			LabelSelector: opts.LabelSelector,
+			FieldSelector: opts.FieldSelector,
+			Limit:         opts.Limit,
+		},
+	}
+
+	switch {
+	case restMapping.Scope.Name() != meta.RESTScopeNameNamespace:
+	case opts.AllNamespaces:
+		if c.namespaceMapper != nil {
+			if scope.namespaces, err = c.environmentNamespaces(ctx); err != nil {
+				return nil, err
+			}
+			// limit is applied after objects are filtered
+			scope.opts.Limit = 0
+		}
+	default:
+		scope.namespace = c.objectNamespace(restMapping, opts.Namespace)
+	}
+
+	return scope, nil
+}
+
+// selects checks whether objects of the namespace are listed.
+func (scope *listScope) selects(namespace string) bool {
+	if scope.namespaces == nil {
+		return true
+	}
+
+	_, ok := scope.namespaces[namespace]
+	return ok
+}
+
+// crListMetadata lists metadata of objects with controller-runtime client.
+func (c *Client) crListMetadata(
+	ctx context.Context, mapping *meta.RESTMapping, namespace string, opts metav1.ListOptions,
+) (*metav1.PartialObjectMetadataList, error) {
+	list := &metav1.PartialObjectMetadataList{}
+	list.SetGroupVersionKind(mapping.GroupVersionKind.GroupVersion().WithKind(mapping.GroupVersionKind.Kind + "List"))
+
+	err := c.crClient.List(ctx, list, &crclient.ListOptions{Namespace: namespace, Limit: opts.Limit, Raw: &opts})
+	return list, err
+}
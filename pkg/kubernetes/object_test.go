@@ -0,0 +1,73 @@
+package kubernetes
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testObjectsSpec = `apiVersion: v1
+kind: List
+items:
+- apiVersion: v1
+  kind: ConfigMap
+  metadata:
+    name: first
+    labels:
+      app: test
+- apiVersion: v1
+  kind: ConfigMap
+  metadata:
+    name: second
+    labels:
+      app: test
+- apiVersion: v1
+  kind: ConfigMap
+  metadata:
+    name: other
+- apiVersion: v1
+  kind: ConfigMap
+  metadata:
+    name: elsewhere
+    namespace: other
+    labels:
+      app: test
+`
+
+func Test_ListAndCount(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	c, err := NewFakeClient()
+	require.NoError(t, err)
+	require.NoError(t, c.Apply(ctx, bytes.NewBufferString(testObjectsSpec)))
+
+	testCases := []struct {
+		name string
+		opts ListOptions
+		exp  int
+	}{
+		{name: "namespace", opts: ListOptions{}, exp: 3},
+		{name: "selector", opts: ListOptions{LabelSelector: "app=test"}, exp: 2},
+		{name: "all namespaces", opts: ListOptions{AllNamespaces: true, LabelSelector: "app=test"}, exp: 3},
+		{name: "limit", opts: ListOptions{Limit: 1}, exp: 1},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			items, err := c.List(ctx, "", "configmaps", testCase.opts)
+			require.NoError(t, err)
+			assert.Len(t, items, testCase.exp)
+
+			n, err := c.Count(ctx, "", "configmaps", testCase.opts)
+			require.NoError(t, err)
+			assert.Equal(t, testCase.exp, n)
+		})
+	}
+}
@@ -0,0 +1,124 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	crclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Subresources supported by Patch.
+const (
+	SubresourceStatus = "status"
+	SubresourceScale  = "scale"
+)
+
+// PatchOptions configure Patch.
+type PatchOptions struct {
+	// Type of patch is one of types.MergePatchType, types.JSONPatchType,
+	// types.StrategicMergePatchType or types.ApplyPatchType; merge patch
+	// is used if it's empty.
+	Type types.PatchType
+	// Subresource is either SubresourceStatus or SubresourceScale; the object
+	// itself is patched if it's empty.
+	Subresource string
+}
+
+// Patch changes the object with patch data and returns the result as
+// unstructured content: the object, or the Scale object if the scale
+// subresource is patched. Unlike Apply, only the fields in data are
+// owned by the "xk6-environment" field manager afterwards.
+func (c *Client) Patch(
+	ctx context.Context, ref ObjectRef, data []byte, opts PatchOptions,
+) (map[string]interface{}, error) {
+	patchType := opts.Type
+	switch patchType {
+	case "":
+		patchType = types.MergePatchType
+	case types.MergePatchType, types.JSONPatchType, types.StrategicMergePatchType, types.ApplyPatchType:
+	default:
+		return nil, fmt.Errorf("%w: unsupported type of patch: %s", ErrInvalid, patchType)
+	}
+
+	switch opts.Subresource {
+	case "", SubresourceStatus, SubresourceScale:
+	default:
+		return nil, fmt.Errorf("%w: unsupported subresource: %s", ErrInvalid, opts.Subresource)
+	}
+
+	restMapping, err := c.restMapping(ref.APIVersion, ref.Kind)
+	if err != nil {
+		return nil, err
+	}
+
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(restMapping.GroupVersionKind)
+	obj.SetName(ref.Name)
+	obj.SetNamespace(c.objectNamespace(restMapping, ref.Namespace))
+
+	return c.patch(ctx, obj, restMapping, crclient.RawPatch(patchType, data), opts.Subresource)
+}
+
+// crPatch patches the object with the controller-runtime client.
+func (c *Client) crPatch(
+	ctx context.Context, obj *unstructured.Unstructured, _ *meta.RESTMapping, patch crclient.Patch, subresource string,
+) (map[string]interface{}, error) {
+	owner := crclient.FieldOwner("xk6-environment")
+
+	switch subresource {
+	case "":
+		if err := c.crClient.Patch(ctx, obj, patch, owner); err != nil {
+			return nil, err
+		}
+	case SubresourceScale:
+		// the response is a Scale object rather than the object itself
+		scale := &unstructured.Unstructured{}
+		scale.SetAPIVersion("autoscaling/v1")
+		scale.SetKind("Scale")
+		scale.SetName(obj.GetName())
+		scale.SetNamespace(obj.GetNamespace())
+
+		err := c.crClient.SubResource(subresource).Patch(ctx, obj, patch, owner,
+			&crclient.SubResourcePatchOptions{SubResourceBody: scale})
+		if err != nil {
+			return nil, err
+		}
+		return scale.Object, nil
+	default:
+		if err := c.crClient.SubResource(subresource).Patch(ctx, obj, patch, owner); err != nil {
+			return nil, err
+		}
+	}
+
+	return obj.Object, nil
+}
+
+// fakePatch approximates Patch of the fake Client with the dynamic client:
+// strategic merge and apply patches are merge patches, and subresources are
+// patched as parts of the object itself.
+func (c *Client) fakePatch(
+	ctx context.Context, obj *unstructured.Unstructured, mapping *meta.RESTMapping, patch crclient.Patch, _ string,
+) (map[string]interface{}, error) {
+	data, err := patch.Data(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	patchType := patch.Type()
+	if patchType == types.StrategicMergePatchType || patchType == types.ApplyPatchType {
+		patchType = types.MergePatchType
+	}
+
+	ri := c.dynamicClient.Resource(mapping.Resource).Namespace(obj.GetNamespace())
+
+	result, err := ri.Patch(ctx, obj.GetName(), patchType, data, metav1.PatchOptions{FieldManager: "xk6-environment"})
+	if err != nil {
+		return nil, err
+	}
+
+	return result.Object, nil
+}
@@ -0,0 +1,193 @@
+package kubernetes
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const testPatchSpec = `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app
+  labels:
+    app: test
+spec:
+  replicas: 1
+`
+
+func Test_Patch(t *testing.T) {
+	t.Parallel()
+
+	ref := ObjectRef{Kind: "Deployment", Name: "app"}
+
+	testCases := []struct {
+		name   string
+		ref    ObjectRef
+		patch  string
+		opts   PatchOptions
+		path   []string
+		value  interface{}
+		expErr error
+	}{
+		{
+			name:  "merge by default",
+			ref:   ref,
+			patch: `{"metadata":{"labels":{"app":"patched"}}}`,
+			path:  []string{"metadata", "labels", "app"},
+			value: "patched",
+		},
+		{
+			name:  "json",
+			ref:   ref,
+			patch: `[{"op":"replace","path":"/spec/replicas","value":2}]`,
+			opts:  PatchOptions{Type: types.JSONPatchType},
+			path:  []string{"spec", "replicas"},
+			value: int64(2),
+		},
+		{
+			name:  "status",
+			ref:   ref,
+			patch: `{"status":{"readyReplicas":1}}`,
+			opts:  PatchOptions{Subresource: SubresourceStatus},
+			path:  []string{"status", "readyReplicas"},
+			value: int64(1),
+		},
+		{
+			name:  "scale",
+			ref:   ref,
+			patch: `{"spec":{"replicas":3}}`,
+			opts:  PatchOptions{Type: types.MergePatchType, Subresource: SubresourceScale},
+			path:  []string{"spec", "replicas"},
+			value: int64(3),
+		},
+		{
+			name:   "unsupported type",
+			ref:    ref,
+			patch:  `{}`,
+			opts:   PatchOptions{Type: "application/unknown"},
+			expErr: ErrInvalid,
+		},
+		{
+			name:   "unsupported subresource",
+			ref:    ref,
+			patch:  `{}`,
+			opts:   PatchOptions{Subresource: "logs"},
+			expErr: ErrInvalid,
+		},
+		{
+			name:   "unknown kind",
+			ref:    ObjectRef{Kind: "Unknown", Name: "app"},
+			patch:  `{}`,
+			expErr: ErrNotFound,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			c, err := NewFakeClient()
+			require.NoError(t, err)
+			require.NoError(t, c.Apply(ctx, bytes.NewBufferString(testPatchSpec)))
+
+			obj, err := c.Patch(ctx, testCase.ref, []byte(testCase.patch), testCase.opts)
+			if testCase.expErr != nil {
+				assert.ErrorIs(t, err, testCase.expErr)
+				return
+			}
+			require.NoError(t, err)
+
+			value, _, err := unstructured.NestedFieldNoCopy(obj, testCase.path...)
+			require.NoError(t, err)
+			assert.Equal(t, testCase.value, value)
+
+			// the change is persisted
+			obj, err = c.Get(ctx, testCase.ref)
+			require.NoError(t, err)
+			value, _, err = unstructured.NestedFieldNoCopy(obj, testCase.path...)
+			require.NoError(t, err)
+			assert.Equal(t, testCase.value, value)
+		})
+	}
+}
+
+const testPatchServerSpec = `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app
+spec:
+  replicas: 1
+  selector:
+    matchLabels:
+      app: test
+  template:
+    metadata:
+      labels:
+        app: test
+    spec:
+      containers:
+      - name: app
+        image: nginx:1.14.2
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: settings
+data:
+  a: "1"
+`
+
+// Test_PatchServer checks patches which are approximated by the fake Client.
+func Test_PatchServer(t *testing.T) {
+	t.Parallel()
+
+	c := newTestClient(t)
+	ctx := context.Background()
+	ref := ObjectRef{Kind: "Deployment", Name: "app"}
+
+	require.NoError(t, c.Apply(ctx, bytes.NewBufferString(testPatchServerSpec)))
+
+	// status is not changed through the object itself
+	_, err := c.Patch(ctx, ref, []byte(`{"status":{"readyReplicas":1}}`), PatchOptions{})
+	require.NoError(t, err)
+	obj, err := c.Get(ctx, ref)
+	require.NoError(t, err)
+	_, found, err := unstructured.NestedInt64(obj, "status", "readyReplicas")
+	require.NoError(t, err)
+	assert.False(t, found)
+
+	obj, err = c.Patch(ctx, ref, []byte(`{"status":{"readyReplicas":1}}`), PatchOptions{Subresource: SubresourceStatus})
+	require.NoError(t, err)
+	readyReplicas, _, err := unstructured.NestedInt64(obj, "status", "readyReplicas")
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), readyReplicas)
+
+	// the result of scale is Scale object
+	scale, err := c.Patch(ctx, ref, []byte(`{"spec":{"replicas":3}}`), PatchOptions{Subresource: SubresourceScale})
+	require.NoError(t, err)
+	assert.Equal(t, "Scale", scale["kind"])
+	replicas, _, err := unstructured.NestedInt64(scale, "spec", "replicas")
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), replicas)
+
+	obj, err = c.Get(ctx, ref)
+	require.NoError(t, err)
+	replicas, _, err = unstructured.NestedInt64(obj, "spec", "replicas")
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), replicas)
+
+	// fields applied before and missing from apply patch are removed
+	obj, err = c.Patch(ctx, ObjectRef{Kind: "ConfigMap", Name: "settings"},
+		[]byte(`{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"settings"},"data":{"b":"2"}}`),
+		PatchOptions{Type: types.ApplyPatchType})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"b": "2"}, obj["data"])
+}
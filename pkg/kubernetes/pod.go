@@ -0,0 +1,44 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// ReadyPod returns the name of the first ready pod matching the label selector.
+func (c *Client) ReadyPod(ctx context.Context, namespace, selector string) (string, error) {
+	pods, err := c.List(ctx, "v1", "Pod", ListOptions{Namespace: namespace, LabelSelector: selector})
+	if err != nil {
+		return "", err
+	}
+
+	for _, item := range pods {
+		var pod corev1.Pod
+		if err = runtime.DefaultUnstructuredConverter.FromUnstructured(item, &pod); err != nil {
+			return "", err
+		}
+
+		if pod.DeletionTimestamp == nil && isPodReady(&pod) {
+			return pod.Name, nil
+		}
+	}
+
+	return "", fmt.Errorf("ready pod %w in namespace %s with selector %s", ErrNotFound, namespace, selector)
+}
+
+func isPodReady(pod *corev1.Pod) bool {
+	if pod.Status.Phase != corev1.PodRunning {
+		return false
+	}
+
+	for _, cond := range pod.Status.Conditions {
+		if cond.Type == corev1.PodReady {
+			return cond.Status == corev1.ConditionTrue
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,82 @@
+package kubernetes
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testPodsSpec = `apiVersion: v1
+kind: List
+items:
+- apiVersion: v1
+  kind: Pod
+  metadata:
+    name: pending
+    labels:
+      app: test
+  status:
+    phase: Pending
+- apiVersion: v1
+  kind: Pod
+  metadata:
+    name: unready
+    labels:
+      app: test
+  status:
+    phase: Running
+    conditions:
+    - type: Ready
+      status: "False"
+- apiVersion: v1
+  kind: Pod
+  metadata:
+    name: terminating
+    labels:
+      app: test
+    deletionTimestamp: "2024-01-01T00:00:00Z"
+  status:
+    phase: Running
+    conditions:
+    - type: Ready
+      status: "True"
+- apiVersion: v1
+  kind: Pod
+  metadata:
+    name: ready
+    labels:
+      app: test
+  status:
+    phase: Running
+    conditions:
+    - type: Ready
+      status: "True"
+- apiVersion: v1
+  kind: Pod
+  metadata:
+    name: other
+  status:
+    phase: Running
+    conditions:
+    - type: Ready
+      status: "True"
+`
+
+func Test_ReadyPod(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	c, err := NewFakeClient()
+	require.NoError(t, err)
+	require.NoError(t, c.Apply(ctx, bytes.NewBufferString(testPodsSpec)))
+
+	pod, err := c.ReadyPod(ctx, "default", "app=test")
+	require.NoError(t, err)
+	assert.Equal(t, "ready", pod)
+
+	_, err = c.ReadyPod(ctx, "default", "app=missing")
+	assert.ErrorIs(t, err, ErrNotFound)
+}
@@ -0,0 +1,88 @@
+package kubernetes
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+
+	"k8s.io/client-go/tools/portforward"
+	"k8s.io/client-go/transport/spdy"
+)
+
+// PortForward is a tunnel from a local port to the port of a pod,
+// through portforward subresource of Kubernetes API.
+type PortForward struct {
+	// LocalPort is the port on 127.0.0.1 which is forwarded.
+	LocalPort uint16
+
+	stopCh    chan struct{}
+	doneCh    chan struct{}
+	closeOnce sync.Once
+}
+
+// ForwardPort opens a tunnel from a random local port to the port of the pod.
+// The tunnel stays open until it's closed or until the pod is gone.
+func (c *Client) ForwardPort(namespace, pod string, port int) (*PortForward, error) {
+	if c.restConfig == nil {
+		return nil, errNoServer
+	}
+
+	transport, upgrader, err := spdy.RoundTripperFor(c.restConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	url := c.clientset.CoreV1().RESTClient().Post().
+		Resource("pods").
+		Namespace(c.namespace(namespace)).
+		Name(pod).
+		SubResource("portforward").
+		URL()
+	dialer := spdy.NewDialer(upgrader, &http.Client{Transport: transport}, http.MethodPost, url)
+
+	pf := &PortForward{
+		stopCh: make(chan struct{}),
+		doneCh: make(chan struct{}),
+	}
+	readyCh := make(chan struct{})
+
+	fw, err := portforward.NewOnAddresses(dialer, []string{"127.0.0.1"}, []string{fmt.Sprintf("0:%d", port)},
+		pf.stopCh, readyCh, io.Discard, io.Discard)
+	if err != nil {
+		return nil, err
+	}
+
+	errCh := make(chan error, 1)
+	go func() {
+		defer close(pf.doneCh)
+		errCh <- fw.ForwardPorts()
+	}()
+
+	select {
+	case <-readyCh:
+	case err = <-errCh:
+		return nil, fmt.Errorf("unable to forward port %d of pod %s: %w", port, pod, err)
+	}
+
+	ports, err := fw.GetPorts()
+	if err != nil {
+		pf.Close()
+		return nil, err
+	}
+	pf.LocalPort = ports[0].Local
+
+	return pf, nil
+}
+
+// Done returns a channel which is closed when the tunnel is closed.
+func (pf *PortForward) Done() <-chan struct{} {
+	return pf.doneCh
+}
+
+// Close closes the tunnel. It's safe to call it concurrently and more than once.
+func (pf *PortForward) Close() {
+	pf.closeOnce.Do(func() {
+		close(pf.stopCh)
+	})
+}
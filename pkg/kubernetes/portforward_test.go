@@ -0,0 +1,41 @@
+package kubernetes
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/rest"
+)
+
+func Test_ForwardPort(t *testing.T) {
+	t.Parallel()
+
+	fake, err := NewFakeClient()
+	require.NoError(t, err)
+
+	_, err = fake.ForwardPort("default", "app", 80)
+	assert.ErrorIs(t, err, errNoServer)
+
+	// API server which doesn't upgrade connections to streams
+	paths := make(chan string, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case paths <- r.Method + " " + r.URL.Path:
+		default:
+		}
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	c, err := NewClientForConfig(context.Background(), &rest.Config{Host: server.URL})
+	require.NoError(t, err)
+
+	_, err = c.ForwardPort("default", "app", 80)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unable to forward port 80 of pod app")
+	assert.Equal(t, "POST /api/v1/namespaces/default/pods/app/portforward", <-paths)
+}
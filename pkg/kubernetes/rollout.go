@@ -0,0 +1,151 @@
+package kubernetes
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	metav1u "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+var errProgressDeadlineExceeded = errors.New("progress deadline exceeded")
+
+// restartedAtAnnotation is set on pod template to restart rollout, as in kubectl.
+const restartedAtAnnotation = "kubectl.kubernetes.io/restartedAt"
+
+// Scale sets the number of replicas of the object through its scale subresource.
+func (c *Client) Scale(ctx context.Context, ref ObjectRef, replicas int32) error {
+	if replicas < 0 {
+		return fmt.Errorf("%w: number of replicas cannot be negative: %d", ErrInvalid, replicas)
+	}
+
+	_, err := c.Patch(ctx, ref, []byte(fmt.Sprintf(`{"spec":{"replicas":%d}}`, replicas)),
+		PatchOptions{Subresource: SubresourceScale})
+	return err
+}
+
+// RolloutRestart restarts rollout of Deployment or StatefulSet, like
+// `kubectl rollout restart`: pods are replaced according to its strategy.
+func (c *Client) RolloutRestart(ctx context.Context, ref ObjectRef) error {
+	restMapping, err := c.restMapping(ref.APIVersion, ref.Kind)
+	if err != nil {
+		return err
+	}
+
+	switch gvk := restMapping.GroupVersionKind; {
+	case gvk.Group == "apps" && (gvk.Kind == "Deployment" || gvk.Kind == "StatefulSet"):
+	default:
+		return fmt.Errorf("%w: rollout of %s cannot be restarted", ErrInvalid, gvk.Kind)
+	}
+
+	patch := fmt.Sprintf(`{"spec":{"template":{"metadata":{"annotations":{%q:%q}}}}}`,
+		restartedAtAnnotation, time.Now().Format(time.RFC3339))
+
+	_, err = c.Patch(ctx, ref, []byte(patch), PatchOptions{})
+	return err
+}
+
+// NewRolloutCondition constructs WaitCondition which is fulfilled when
+// rollout of Deployment, StatefulSet or ReplicaSet is complete, like with
+// `kubectl rollout status`. Namespace is "default" if it's empty.
+func NewRolloutCondition(kind, name, namespace string) (*WaitCondition, error) {
+	if len(namespace) == 0 {
+		namespace = "default"
+	}
+
+	wc := &WaitCondition{
+		interval: defaultInterval,
+		timeout:  defaultTimeout,
+		resource: resource{Kind: kind, Name: name, Namespace: namespace},
+		state:    state{stateType: rollout},
+	}
+
+	if !wc.Validate() {
+		return nil, fmt.Errorf("%w: rollout status requires kind and name", ErrInvalid)
+	}
+
+	return wc, nil
+}
+
+func (wc *WaitCondition) rollout() {
+	wc.condF = func(c *Client) func(ctx context.Context) (done bool, err error) {
+		return func(ctx context.Context) (done bool, err error) {
+			restMapping, err := c.restMapping("", wc.Kind)
+			if err != nil {
+				return false, err
+			}
+
+			// unlike other conditions, the object must exist already
+			obj, err := c.dynamicClient.
+				Resource(restMapping.Resource).
+				Namespace(wc.Namespace).
+				Get(ctx, wc.Name, metav1.GetOptions{})
+			if err != nil {
+				return false, err
+			}
+
+			return rolloutComplete(restMapping.GroupVersionKind.Kind, obj.UnstructuredContent())
+		}
+	}
+}
+
+// rolloutComplete checks whether the controller has observed the latest spec
+// and all replicas are updated and available. StatefulSet pods are expected
+// to be updated only up to the partition, as in kubectl.
+func rolloutComplete(kind string, obj map[string]interface{}) (bool, error) {
+	switch kind {
+	case "Deployment", "StatefulSet", "ReplicaSet":
+	default:
+		return false, fmt.Errorf("%w: rollout status of %s is not supported", ErrInvalid, kind)
+	}
+
+	generation, _, _ := metav1u.NestedInt64(obj, "metadata", "generation")
+	observedGeneration, _, _ := metav1u.NestedInt64(obj, "status", "observedGeneration")
+	if observedGeneration < generation {
+		return false, nil
+	}
+
+	if kind == "Deployment" {
+		conditions, _, _ := metav1u.NestedSlice(obj, "status", "conditions")
+		cond := meta.FindStatusCondition(getConditions(conditions), "Progressing")
+		if cond != nil && cond.Reason == "ProgressDeadlineExceeded" {
+			return false, fmt.Errorf("rollout of %s failed: %w", kind, errProgressDeadlineExceeded)
+		}
+	}
+
+	replicas, found, _ := metav1u.NestedInt64(obj, "spec", "replicas")
+	if !found {
+		replicas = 1
+	}
+
+	// pods of StatefulSet are updated only up to the partition, or not at all
+	// with OnDelete strategy, as in kubectl
+	expectedUpdated := replicas
+	if kind == "StatefulSet" {
+		strategy, _, _ := metav1u.NestedString(obj, "spec", "updateStrategy", "type")
+		if strategy == "OnDelete" {
+			return false, fmt.Errorf("%w: rollout status of %s with OnDelete strategy is not supported",
+				ErrInvalid, kind)
+		}
+
+		partition, _, _ := metav1u.NestedInt64(obj, "spec", "updateStrategy", "rollingUpdate", "partition")
+		if partition > 0 {
+			expectedUpdated = max(replicas-partition, 0)
+		}
+	}
+
+	current, _, _ := metav1u.NestedInt64(obj, "status", "replicas")
+	available, _, _ := metav1u.NestedInt64(obj, "status", "availableReplicas")
+	done := current == replicas && available == replicas
+
+	// ReplicaSet has only one revision of pods
+	if kind != "ReplicaSet" {
+		updated, _, _ := metav1u.NestedInt64(obj, "status", "updatedReplicas")
+		done = done && updated >= expectedUpdated
+	}
+
+	return done, nil
+}
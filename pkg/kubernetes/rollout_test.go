@@ -0,0 +1,160 @@
+package kubernetes
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/json"
+)
+
+func Test_rolloutComplete(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name   string
+		kind   string
+		obj    string
+		done   bool
+		expErr error
+	}{
+		{
+			name: "complete",
+			kind: "Deployment",
+			obj: `{"metadata":{"generation":2},"spec":{"replicas":3},
+"status":{"observedGeneration":2,"replicas":3,"updatedReplicas":3,"availableReplicas":3}}`,
+			done: true,
+		},
+		{
+			name: "default replicas",
+			kind: "StatefulSet",
+			obj: `{"metadata":{"generation":1},"spec":{},
+"status":{"observedGeneration":1,"replicas":1,"updatedReplicas":1,"availableReplicas":1}}`,
+			done: true,
+		},
+		{
+			name: "not observed",
+			kind: "Deployment",
+			obj: `{"metadata":{"generation":3},"spec":{"replicas":3},
+"status":{"observedGeneration":2,"replicas":3,"updatedReplicas":3,"availableReplicas":3}}`,
+		},
+		{
+			name: "old replicas",
+			kind: "Deployment",
+			obj: `{"metadata":{"generation":2},"spec":{"replicas":3},
+"status":{"observedGeneration":2,"replicas":4,"updatedReplicas":3,"availableReplicas":3}}`,
+		},
+		{
+			name: "not updated",
+			kind: "StatefulSet",
+			obj: `{"metadata":{"generation":2},"spec":{"replicas":3},
+"status":{"observedGeneration":2,"replicas":3,"updatedReplicas":2,"availableReplicas":3}}`,
+		},
+		{
+			name: "partition",
+			kind: "StatefulSet",
+			obj: `{"metadata":{"generation":2},"spec":{"replicas":3,
+"updateStrategy":{"type":"RollingUpdate","rollingUpdate":{"partition":2}}},
+"status":{"observedGeneration":2,"replicas":3,"updatedReplicas":1,"availableReplicas":3}}`,
+			done: true,
+		},
+		{
+			name: "partition not updated",
+			kind: "StatefulSet",
+			obj: `{"metadata":{"generation":2},"spec":{"replicas":3,
+"updateStrategy":{"type":"RollingUpdate","rollingUpdate":{"partition":1}}},
+"status":{"observedGeneration":2,"replicas":3,"updatedReplicas":1,"availableReplicas":3}}`,
+		},
+		{
+			name: "on delete",
+			kind: "StatefulSet",
+			obj: `{"metadata":{"generation":2},"spec":{"replicas":3,"updateStrategy":{"type":"OnDelete"}},
+"status":{"observedGeneration":2,"replicas":3,"updatedReplicas":3,"availableReplicas":3}}`,
+			expErr: ErrInvalid,
+		},
+		{
+			name: "not available",
+			kind: "ReplicaSet",
+			obj: `{"metadata":{"generation":1},"spec":{"replicas":3},
+"status":{"observedGeneration":1,"replicas":3,"availableReplicas":2}}`,
+		},
+		{
+			name: "replica set",
+			kind: "ReplicaSet",
+			obj: `{"metadata":{"generation":1},"spec":{"replicas":3},
+"status":{"observedGeneration":1,"replicas":3,"availableReplicas":3}}`,
+			done: true,
+		},
+		{
+			name: "progress deadline exceeded",
+			kind: "Deployment",
+			obj: `{"metadata":{"generation":1},"spec":{"replicas":3},"status":{"observedGeneration":1,
+"conditions":[{"type":"Progressing","status":"False","reason":"ProgressDeadlineExceeded"}]}}`,
+			expErr: errProgressDeadlineExceeded,
+		},
+		{
+			name:   "unsupported kind",
+			kind:   "Pod",
+			obj:    `{}`,
+			expErr: ErrInvalid,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			// numbers are int64 as in objects of dynamic client
+			var obj map[string]interface{}
+			require.NoError(t, json.Unmarshal([]byte(testCase.obj), &obj))
+
+			done, err := rolloutComplete(testCase.kind, obj)
+			if testCase.expErr != nil {
+				assert.ErrorIs(t, err, testCase.expErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, testCase.done, done)
+		})
+	}
+}
+
+func Test_ScaleAndRollout(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	c, err := NewFakeClient()
+	require.NoError(t, err)
+	require.NoError(t, c.Apply(ctx, bytes.NewBufferString(testPatchSpec)))
+
+	ref := ObjectRef{Kind: "Deployment", Name: "app"}
+
+	require.NoError(t, c.Scale(ctx, ref, 3))
+	require.NoError(t, c.RolloutRestart(ctx, ref))
+	assert.ErrorIs(t, c.RolloutRestart(ctx, ObjectRef{Kind: "ConfigMap", Name: "app"}), ErrInvalid)
+
+	obj, err := c.Get(ctx, ref)
+	require.NoError(t, err)
+
+	replicas, _, _ := unstructured.NestedInt64(obj, "spec", "replicas")
+	assert.Equal(t, int64(3), replicas)
+	annotations, _, _ := unstructured.NestedStringMap(obj, "spec", "template", "metadata", "annotations")
+	assert.Contains(t, annotations, restartedAtAnnotation)
+
+	// there is no controller in the fake client, so rollout is simulated
+	_, err = c.Patch(ctx, ref,
+		[]byte(`{"status":{"observedGeneration":0,"replicas":3,"updatedReplicas":3,"availableReplicas":3}}`),
+		PatchOptions{Subresource: SubresourceStatus})
+	require.NoError(t, err)
+
+	wc, err := NewRolloutCondition("deployments", "app", "")
+	require.NoError(t, err)
+	wc.TimeParams(10*time.Millisecond, time.Second)
+	wc.Build()
+	assert.NoError(t, c.Wait(ctx, wc))
+}
@@ -0,0 +1,24 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// SecretData returns the value of the key in the secret.
+func (c *Client) SecretData(ctx context.Context, namespace, name, key string) ([]byte, error) {
+	var secret corev1.Secret
+	ref := ObjectRef{APIVersion: "v1", Kind: "Secret", Name: name, Namespace: namespace}
+	if err := c.getTyped(ctx, ref, &secret); err != nil {
+		return nil, err
+	}
+
+	data, ok := secret.Data[key]
+	if !ok {
+		return nil, fmt.Errorf("key %s %w in secret %s/%s", key, ErrNotFound, namespace, name)
+	}
+
+	return data, nil
+}
@@ -0,0 +1,38 @@
+package kubernetes
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testSecretSpec = `apiVersion: v1
+kind: Secret
+metadata:
+  name: kubeconfig
+  namespace: vcluster
+data:
+  config: YXBpVmVyc2lvbjogdjE=
+`
+
+func Test_SecretData(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	c, err := NewFakeClient()
+	require.NoError(t, err)
+	require.NoError(t, c.Apply(ctx, bytes.NewBufferString(testSecretSpec)))
+
+	data, err := c.SecretData(ctx, "vcluster", "kubeconfig", "config")
+	require.NoError(t, err)
+	assert.Equal(t, "apiVersion: v1", string(data))
+
+	_, err = c.SecretData(ctx, "vcluster", "kubeconfig", "missing")
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	_, err = c.SecretData(ctx, "vcluster", "missing", "config")
+	assert.True(t, IsNotFound(err))
+}
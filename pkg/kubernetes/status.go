@@ -0,0 +1,30 @@
+package kubernetes
+
+import (
+	"context"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	metav1u "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// SetStatus replaces .status of the object with the given value. Normally
+// .status is owned by Kubernetes controllers, so this is meant to simulate
+// them, e.g. in environment without controllers.
+func (c *Client) SetStatus(ctx context.Context, ref ObjectRef, status map[string]interface{}) error {
+	ri, err := c.resource(ref.APIVersion, ref.Kind, ref.Namespace)
+	if err != nil {
+		return err
+	}
+
+	obj, err := ri.Get(ctx, ref.Name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+
+	if err = metav1u.SetNestedField(obj.Object, status, "status"); err != nil {
+		return err
+	}
+
+	_, err = ri.UpdateStatus(ctx, obj, metav1.UpdateOptions{FieldManager: "xk6-environment"})
+	return err
+}
@@ -0,0 +1,111 @@
+package kubernetes
+
+import (
+	"context"
+	"errors"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// EnvironmentLabel is set on objects created by xk6-environment
+// and holds the name of the environment.
+const EnvironmentLabel = "xk6-environment/name"
+
+// ObjectTracker is implemented by environments which share the cluster
+// with other objects and must remove only the objects they created.
+type ObjectTracker interface {
+	// TrackingLabels returns labels to set on objects created by Client.
+	TrackingLabels() map[string]string
+	// Track records the resource and the namespace of an object labelled by Client.
+	Track(r TrackedResource)
+}
+
+// TrackedResource is a resource and a namespace (empty for cluster-scoped
+// resources) where objects were labelled by Client.
+type TrackedResource struct {
+	Resource  schema.GroupVersionResource
+	Namespace string
+}
+
+// SetObjectTracker configures Client to label the objects it creates
+// in Apply with labels of the given ObjectTracker.
+func (c *Client) SetObjectTracker(t ObjectTracker) {
+	c.tracker = t
+	c.trackingLabels = t.TrackingLabels()
+}
+
+// track labels the object if it is about to be created. Objects that
+// existed before are labelled only if they were created by Client:
+// otherwise, they would be removed together with the environment.
+func (c *Client) track(ctx context.Context, obj *unstructured.Unstructured, mapping *meta.RESTMapping) error {
+	ri := c.dynamicClient.Resource(mapping.Resource).Namespace(obj.GetNamespace())
+
+	existing, err := ri.Get(ctx, obj.GetName(), metav1.GetOptions{})
+	switch {
+	case apierrors.IsNotFound(err):
+	case err != nil:
+		return err
+	case !labels.SelectorFromSet(c.trackingLabels).Matches(labels.Set(existing.GetLabels())):
+		return nil
+	}
+
+	l := obj.GetLabels()
+	if l == nil {
+		l = make(map[string]string)
+	}
+	for k, v := range c.trackingLabels {
+		l[k] = v
+	}
+	obj.SetLabels(l)
+
+	c.tracker.Track(TrackedResource{Resource: mapping.Resource, Namespace: obj.GetNamespace()})
+
+	return nil
+}
+
+// DeleteLabelled removes objects which have the given labels. Only the given
+// resources are searched, and within the given namespaces only.
+func (c *Client) DeleteLabelled(ctx context.Context, l map[string]string, resources []TrackedResource) error {
+	selector := labels.SelectorFromSet(l).String()
+	propagation := metav1.DeletePropagationBackground
+	var errs []error
+
+	for _, r := range resources {
+		ri := c.dynamicClient.Resource(r.Resource).Namespace(r.Namespace)
+
+		objs, err := ri.List(ctx, metav1.ListOptions{LabelSelector: selector})
+		if err != nil {
+			// the namespace may have been removed together with its objects
+			if !apierrors.IsNotFound(err) {
+				errs = append(errs, err)
+			}
+			continue
+		}
+
+		for _, obj := range objs.Items {
+			err := ri.Delete(ctx, obj.GetName(), metav1.DeleteOptions{
+				PropagationPolicy: &propagation,
+			})
+			if err != nil && !apierrors.IsNotFound(err) {
+				errs = append(errs, err)
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// ServerVersion returns the version of Kubernetes API server.
+func (c *Client) ServerVersion() (string, error) {
+	v, err := c.discoveryClient.ServerVersion()
+	if err != nil {
+		return "", err
+	}
+
+	return v.String(), nil
+}
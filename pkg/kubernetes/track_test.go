@@ -0,0 +1,59 @@
+package kubernetes
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+type testTracker struct {
+	resources map[TrackedResource]struct{}
+}
+
+func (testTracker) TrackingLabels() map[string]string {
+	return map[string]string{EnvironmentLabel: "test"}
+}
+
+func (t testTracker) Track(r TrackedResource) {
+	t.resources[r] = struct{}{}
+}
+
+func Test_DeleteLabelled(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	c, err := NewFakeClient()
+	require.NoError(t, err)
+
+	// pod existing before the environment
+	require.NoError(t, c.Apply(ctx, bytes.NewBufferString(testPod)))
+
+	tracker := testTracker{resources: map[TrackedResource]struct{}{}}
+	c.SetObjectTracker(tracker)
+	require.NoError(t, c.Apply(ctx, bytes.NewBufferString(testPod)))
+	require.NoError(t, c.Apply(ctx, bytes.NewBufferString(`apiVersion: v1
+kind: Pod
+metadata:
+  name: created
+  namespace: default
+`)))
+
+	pods, err := c.List(ctx, "", "Pod", ListOptions{Namespace: "default"})
+	require.NoError(t, err)
+	assert.Len(t, pods, 2)
+
+	podResource := schema.GroupVersionResource{Version: "v1", Resource: "pods"}
+	assert.Equal(t, map[TrackedResource]struct{}{{Resource: podResource, Namespace: "default"}: {}}, tracker.resources)
+
+	resources := []TrackedResource{{Resource: podResource, Namespace: "default"}}
+	require.NoError(t, c.DeleteLabelled(ctx, tracker.TrackingLabels(), resources))
+
+	pods, err = c.List(ctx, "", "Pod", ListOptions{Namespace: "default"})
+	require.NoError(t, err)
+	require.Len(t, pods, 1)
+	assert.Equal(t, "nginx", pods[0]["metadata"].(map[string]interface{})["name"])
+}
@@ -0,0 +1,296 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// Tunnel is a local listener which forwards connections to a port of a pod,
+// like `kubectl port-forward`. Unlike PortForward, it outlives the pod: when
+// the pod is gone, connections are forwarded to the next pod chosen for the
+// target, e.g. another ready pod of the service.
+type Tunnel struct {
+	// LocalPort is the port on 127.0.0.1 which is forwarded.
+	LocalPort int
+
+	c         *Client
+	namespace string
+	// target chooses the pod and its port to forward to
+	target func(ctx context.Context) (pod string, port int, err error)
+	// forwardPort opens a port forward to the pod, it's ForwardPort of the client
+	forwardPort func(namespace, pod string, port int) (*PortForward, error)
+
+	listener net.Listener
+	ctx      context.Context //nolint:containedctx
+	cancel   context.CancelFunc
+
+	mu  sync.Mutex
+	pod string
+	pf  *PortForward
+}
+
+// ForwardPod opens a tunnel from a random local port to the port of the pod.
+// Namespace is "default" if it's empty.
+func (c *Client) ForwardPod(ctx context.Context, namespace, pod string, port int) (*Tunnel, error) {
+	if len(namespace) == 0 {
+		namespace = "default"
+	}
+	if port <= 0 {
+		return nil, fmt.Errorf("%w: port of pod %s is required", ErrInvalid, pod)
+	}
+
+	return c.newTunnel(ctx, namespace, func(context.Context) (string, int, error) {
+		return pod, port, nil
+	})
+}
+
+// ForwardService opens a tunnel from a random local port to the port of the
+// service: connections are forwarded to the target port of a ready pod selected
+// by the service. port can be 0 if the service has only one port. Namespace
+// is "default" if it's empty.
+func (c *Client) ForwardService(ctx context.Context, namespace, service string, port int) (*Tunnel, error) {
+	if len(namespace) == 0 {
+		namespace = "default"
+	}
+
+	var svc corev1.Service
+	ref := ObjectRef{APIVersion: "v1", Kind: "Service", Name: service, Namespace: namespace}
+	if err := c.getTyped(ctx, ref, &svc); err != nil {
+		return nil, err
+	}
+
+	servicePort, err := findServicePort(&svc, port)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(svc.Spec.Selector) == 0 {
+		return nil, fmt.Errorf("%w: service %s has no selector of pods", ErrInvalid, service)
+	}
+	selector := labels.SelectorFromSet(svc.Spec.Selector).String()
+
+	return c.newTunnel(ctx, namespace, func(ctx context.Context) (string, int, error) {
+		pod, err := c.ReadyPod(ctx, namespace, selector)
+		if err != nil {
+			return "", 0, err
+		}
+
+		targetPort, err := c.podPort(ctx, namespace, pod, servicePort)
+		return pod, targetPort, err
+	})
+}
+
+// findServicePort returns the port of the service with the given number,
+// or its only port if port is 0.
+func findServicePort(svc *corev1.Service, port int) (*corev1.ServicePort, error) {
+	for i := range svc.Spec.Ports {
+		if int(svc.Spec.Ports[i].Port) == port || (port == 0 && len(svc.Spec.Ports) == 1) {
+			return &svc.Spec.Ports[i], nil
+		}
+	}
+
+	if port == 0 {
+		return nil, fmt.Errorf("%w: service %s has %d ports, port is required", ErrInvalid, svc.Name, len(svc.Spec.Ports))
+	}
+
+	return nil, fmt.Errorf("port %d of service %s %w", port, svc.Name, ErrNotFound)
+}
+
+// podPort returns the number of the target port of the service in the pod;
+// target port can be given by name of container port.
+func (c *Client) podPort(ctx context.Context, namespace, pod string, servicePort *corev1.ServicePort) (int, error) {
+	switch {
+	case servicePort.TargetPort.Type == intstr.Int && servicePort.TargetPort.IntVal == 0:
+		// target port is the same as port by default
+		return int(servicePort.Port), nil
+	case servicePort.TargetPort.Type == intstr.Int:
+		return servicePort.TargetPort.IntValue(), nil
+	}
+
+	var p corev1.Pod
+	if err := c.getTyped(ctx, ObjectRef{APIVersion: "v1", Kind: "Pod", Name: pod, Namespace: namespace}, &p); err != nil {
+		return 0, err
+	}
+
+	for _, container := range p.Spec.Containers {
+		for _, containerPort := range container.Ports {
+			if containerPort.Name == servicePort.TargetPort.StrVal {
+				return int(containerPort.ContainerPort), nil
+			}
+		}
+	}
+
+	return 0, fmt.Errorf("port %s of pod %s %w", servicePort.TargetPort.StrVal, pod, ErrNotFound)
+}
+
+func (c *Client) newTunnel(
+	ctx context.Context, namespace string, target func(ctx context.Context) (string, int, error),
+) (*Tunnel, error) {
+	t := &Tunnel{
+		c:           c,
+		namespace:   namespace,
+		target:      target,
+		forwardPort: c.ForwardPort,
+	}
+	if err := t.open(ctx); err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
+
+// open forwards the first pod and starts serving connections.
+func (t *Tunnel) open(ctx context.Context) error {
+	// the first pod is forwarded right away, so that errors are reported
+	if _, err := t.forward(ctx); err != nil {
+		return err
+	}
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.closeForward()
+		return err
+	}
+
+	t.listener = listener
+	t.LocalPort = listener.Addr().(*net.TCPAddr).Port //nolint:forcetypeassert
+	t.ctx, t.cancel = context.WithCancel(context.Background())
+
+	go t.serve()
+	go t.monitor()
+
+	return nil
+}
+
+// URL returns the local URL of the tunnel with the given scheme, like "http".
+func (t *Tunnel) URL(scheme string) string {
+	return scheme + "://" + net.JoinHostPort("127.0.0.1", strconv.Itoa(t.LocalPort))
+}
+
+// Close closes the tunnel.
+func (t *Tunnel) Close() {
+	t.cancel()
+	_ = t.listener.Close()
+	t.closeForward()
+}
+
+// forward returns the open port forward, opening a new one to the pod chosen
+// for the target if the previous one is closed.
+func (t *Tunnel) forward(ctx context.Context) (*PortForward, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.pf != nil {
+		select {
+		case <-t.pf.Done():
+		default:
+			return t.pf, nil
+		}
+	}
+
+	pod, port, err := t.target(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	pf, err := t.forwardPort(t.namespace, pod, port)
+	if err != nil {
+		return nil, err
+	}
+
+	t.pod, t.pf = pod, pf
+	return pf, nil
+}
+
+func (t *Tunnel) closeForward() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.pf != nil {
+		t.pf.Close()
+	}
+}
+
+func (t *Tunnel) serve() {
+	for {
+		conn, err := t.listener.Accept()
+		if err != nil {
+			// the tunnel is closed
+			return
+		}
+		go t.handle(conn)
+	}
+}
+
+func (t *Tunnel) handle(conn net.Conn) {
+	defer conn.Close() //nolint:errcheck
+
+	var upstream net.Conn
+	// the port forward may be noticed to be closed only on dial,
+	// then it's replaced once
+	for attempt := 0; attempt < 2 && upstream == nil; attempt++ {
+		pf, err := t.forward(t.ctx)
+		if err != nil {
+			return
+		}
+
+		if upstream, err = net.Dial("tcp", net.JoinHostPort("127.0.0.1", strconv.Itoa(int(pf.LocalPort)))); err != nil {
+			pf.Close()
+		}
+	}
+	if upstream == nil {
+		return
+	}
+	defer upstream.Close() //nolint:errcheck
+
+	done := make(chan struct{}, 2)
+	go func() {
+		_, _ = io.Copy(upstream, conn)
+		done <- struct{}{}
+	}()
+	go func() {
+		_, _ = io.Copy(conn, upstream)
+		done <- struct{}{}
+	}()
+
+	// either side closed the connection, or the tunnel is closed
+	select {
+	case <-done:
+	case <-t.ctx.Done():
+	}
+}
+
+// monitor closes the port forward when its pod is gone or not ready anymore,
+// so that the next connection is forwarded to another pod. Port forward
+// itself may stay open for a while after the pod is gone.
+func (t *Tunnel) monitor() {
+	ticker := time.NewTicker(defaultInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-t.ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		t.mu.Lock()
+		pod, pf := t.pod, t.pf
+		t.mu.Unlock()
+
+		var p corev1.Pod
+		err := t.c.getTyped(t.ctx, ObjectRef{APIVersion: "v1", Kind: "Pod", Name: pod, Namespace: t.namespace}, &p)
+		if IsNotFound(err) || (err == nil && (p.DeletionTimestamp != nil || !isPodReady(&p))) {
+			pf.Close()
+		}
+	}
+}
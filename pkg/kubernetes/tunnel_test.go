@@ -0,0 +1,260 @@
+package kubernetes
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+const testTunnelSpec = `apiVersion: v1
+kind: List
+items:
+- apiVersion: v1
+  kind: Service
+  metadata:
+    name: single
+  spec:
+    selector:
+      app: test
+    ports:
+    - port: 80
+- apiVersion: v1
+  kind: Service
+  metadata:
+    name: multiple
+  spec:
+    selector:
+      app: test
+    ports:
+    - name: http
+      port: 80
+      targetPort: 8080
+    - name: metrics
+      port: 9090
+      targetPort: metrics
+- apiVersion: v1
+  kind: Pod
+  metadata:
+    name: app
+    labels:
+      app: test
+  spec:
+    containers:
+    - name: app
+      image: app
+      ports:
+      - name: metrics
+        containerPort: 9100
+`
+
+func Test_servicePort(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		service    string
+		port       int
+		targetPort int
+		expErr     error
+	}{
+		{service: "single", port: 0, targetPort: 80},
+		{service: "single", port: 80, targetPort: 80},
+		{service: "single", port: 81, expErr: ErrNotFound},
+		{service: "multiple", port: 0, expErr: ErrInvalid},
+		{service: "multiple", port: 80, targetPort: 8080},
+		// target port given by name of container port
+		{service: "multiple", port: 9090, targetPort: 9100},
+	}
+
+	ctx := context.Background()
+	c, err := NewFakeClient()
+	require.NoError(t, err)
+	require.NoError(t, c.Apply(ctx, bytes.NewBufferString(testTunnelSpec)))
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.service+":"+strconv.Itoa(testCase.port), func(t *testing.T) {
+			t.Parallel()
+
+			var svc corev1.Service
+			require.NoError(t, c.getTyped(ctx, ObjectRef{Kind: "Service", Name: testCase.service}, &svc))
+
+			servicePort, err := findServicePort(&svc, testCase.port)
+			if testCase.expErr != nil {
+				assert.ErrorIs(t, err, testCase.expErr)
+				return
+			}
+			require.NoError(t, err)
+
+			targetPort, err := c.podPort(ctx, "default", "app", servicePort)
+			require.NoError(t, err)
+			assert.Equal(t, testCase.targetPort, targetPort)
+		})
+	}
+}
+
+// testPodServer stands for the port of a pod, it responds with the name of the pod.
+func testPodServer(t *testing.T, pod string) uint16 {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(pod))
+	}))
+	t.Cleanup(server.Close)
+
+	port, err := strconv.Atoi(server.URL[len("http://127.0.0.1:"):])
+	require.NoError(t, err)
+
+	return uint16(port)
+}
+
+// newTestTunnel opens a tunnel which chooses the given pods in turn; port forwards
+// to the pods are substituted by their servers.
+func newTestTunnel(t *testing.T, c *Client, pods ...string) (*Tunnel, func() []*PortForward) {
+	t.Helper()
+
+	ports := make(map[string]uint16, len(pods))
+	for _, pod := range pods {
+		ports[pod] = testPodServer(t, pod)
+	}
+
+	var (
+		mu       sync.Mutex
+		next     int
+		forwards []*PortForward
+	)
+	tunnel := &Tunnel{
+		c:         c,
+		namespace: "default",
+		target: func(context.Context) (string, int, error) {
+			mu.Lock()
+			defer mu.Unlock()
+
+			pod := pods[next%len(pods)]
+			next++
+			return pod, 80, nil
+		},
+		forwardPort: func(_, pod string, _ int) (*PortForward, error) {
+			pf := &PortForward{
+				LocalPort: ports[pod],
+				stopCh:    make(chan struct{}),
+				doneCh:    make(chan struct{}),
+			}
+			go func() {
+				<-pf.stopCh
+				close(pf.doneCh)
+			}()
+
+			mu.Lock()
+			defer mu.Unlock()
+			forwards = append(forwards, pf)
+			return pf, nil
+		},
+	}
+	require.NoError(t, tunnel.open(context.Background()))
+	t.Cleanup(tunnel.Close)
+
+	return tunnel, func() []*PortForward {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]*PortForward(nil), forwards...)
+	}
+}
+
+// runningPod returns the running pod, ready or not.
+func runningPod(name string, ready bool) *corev1.Pod {
+	status := corev1.ConditionFalse
+	if ready {
+		status = corev1.ConditionTrue
+	}
+
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		Status: corev1.PodStatus{
+			Phase:      corev1.PodRunning,
+			Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: status}},
+		},
+	}
+}
+
+// getBody gets the URL with a new connection, so that it's forwarded to the current pod.
+func getBody(url string) (string, error) {
+	client := &http.Client{Transport: &http.Transport{DisableKeepAlives: true}}
+	resp, err := client.Get(url) //nolint:noctx
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close() //nolint:errcheck
+
+	body, err := io.ReadAll(resp.Body)
+	return string(body), err
+}
+
+func Test_TunnelForwardsConnections(t *testing.T) {
+	t.Parallel()
+
+	c, err := NewFakeClient()
+	require.NoError(t, err)
+
+	tunnel, _ := newTestTunnel(t, c, "app-0")
+
+	body, err := getBody(tunnel.URL("http"))
+	require.NoError(t, err)
+	assert.Equal(t, "app-0", body)
+
+	tunnel.Close()
+	_, err = getBody(tunnel.URL("http"))
+	assert.Error(t, err)
+}
+
+func Test_TunnelFailover(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	c, err := NewFakeClient()
+	require.NoError(t, err)
+
+	for _, name := range []string{"app-0", "app-1"} {
+		require.NoError(t, c.createTyped(ctx, "v1", "Pod", "default", runningPod(name, true)))
+	}
+
+	tunnel, forwards := newTestTunnel(t, c, "app-0", "app-1")
+
+	body, err := getBody(tunnel.URL("http"))
+	require.NoError(t, err)
+	assert.Equal(t, "app-0", body)
+
+	// the first port forward is closed, e.g. the pod is gone
+	first := forwards()[0]
+	first.Close()
+	<-first.Done()
+
+	body, err = getBody(tunnel.URL("http"))
+	require.NoError(t, err)
+	assert.Equal(t, "app-1", body)
+
+	// closing it again, as monitor may do, is safe
+	first.Close()
+
+	// the pod is running, but not ready anymore, so monitor closes its port forward
+	status, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&runningPod("app-1", false).Status)
+	require.NoError(t, err)
+	require.NoError(t, c.SetStatus(ctx, ObjectRef{Kind: "Pod", Name: "app-1"}, status))
+
+	assert.Eventually(t, func() bool {
+		body, err := getBody(tunnel.URL("http"))
+		return err == nil && body == "app-0"
+	}, 3*defaultInterval, 100*time.Millisecond)
+	assert.Len(t, forwards(), 3)
+}
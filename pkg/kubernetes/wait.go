@@ -2,15 +2,20 @@ package kubernetes
 
 import (
 	"context"
+	"fmt"
 
 	"k8s.io/apimachinery/pkg/util/wait"
 )
 
 // Wait blocks execution until wait condition is fulfilled.
 func (c *Client) Wait(ctx context.Context, wc *WaitCondition) error {
-	if err := wait.PollUntilContextTimeout(ctx, wc.interval, wc.timeout, true, wc.condF(c)); err != nil {
-		return err
+	wc.Namespace = c.namespace(wc.Namespace)
+
+	err := wait.PollUntilContextTimeout(ctx, wc.interval, wc.timeout, true, wc.condF(c))
+	// interruption of the test itself is not a timeout of condition
+	if wait.Interrupted(err) && ctx.Err() == nil {
+		return fmt.Errorf("%w after %s waiting for %s %s/%s", ErrTimeout, wc.timeout, wc.Kind, wc.Namespace, wc.Name)
 	}
 
-	return nil
+	return err
 }
@@ -5,9 +5,11 @@ import (
 	"fmt"
 	"time"
 
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	metav1u "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
 )
 
 // WaitCondition indicates how long to wait
@@ -49,18 +51,26 @@ const (
 	event
 	statusCondition
 	statusCustom
+	rollout
+)
+
+// Defaults of polling interval and timeout of waiting.
+const (
+	defaultInterval = 2 * time.Second
+	defaultTimeout  = 1 * time.Hour
 )
 
 // NewWaitCondition constructs WaitCondition from provided configuration.
 func NewWaitCondition(conditionArg interface{}) (wc *WaitCondition, err error) {
 	waitOptions, ok := conditionArg.(map[string]interface{})
 	if !ok {
-		err = fmt.Errorf("wait() requires an object that can be converted to map[string]interface{}, got: %+v", conditionArg)
+		err = fmt.Errorf("%w: wait() requires an object that can be converted to map[string]interface{}, got: %+v",
+			ErrInvalid, conditionArg)
 		return
 	}
 	wc = &WaitCondition{}
 	// set defaults
-	wc.interval, wc.timeout = 2*time.Second, 1*time.Hour
+	wc.interval, wc.timeout = defaultInterval, defaultTimeout
 
 	// extract whatever possible
 	wc.Kind, _ = waitOptions["kind"].(string)
@@ -76,7 +86,7 @@ func NewWaitCondition(conditionArg interface{}) (wc *WaitCondition, err error) {
 
 	wc.DeriveType()
 	if !wc.Validate() {
-		return nil, fmt.Errorf("format of condition for wait() is invalid; refer to documentation")
+		return nil, fmt.Errorf("%w: format of condition for wait() is invalid; refer to documentation", ErrInvalid)
 	}
 	return
 }
@@ -101,6 +111,23 @@ func (wc *WaitCondition) Validate() bool {
 		len(wc.Kind) > 0 && len(wc.Namespace) > 0 && len(wc.Name) > 0
 }
 
+// Condition describes what is waited for: the type of status condition,
+// the reason of event, the key of custom status or "rollout".
+func (wc *WaitCondition) Condition() string {
+	switch wc.stateType {
+	case event:
+		return wc.Reason
+	case statusCondition:
+		return wc.ConditionType
+	case statusCustom:
+		return wc.StatusKey
+	case rollout:
+		return "rollout"
+	default:
+		return ""
+	}
+}
+
 // TimeParams sets time parameters.
 func (wc *WaitCondition) TimeParams(interval, timeout time.Duration) {
 	if interval > 0 {
@@ -120,6 +147,9 @@ func (wc *WaitCondition) Build() {
 	case statusCustom:
 		wc.statusCustom()
 
+	case rollout:
+		wc.rollout()
+
 	default: // == Event
 		wc.event()
 	}
@@ -129,8 +159,9 @@ func (wc *WaitCondition) statusCondition() {
 	wc.condF = func(c *Client) func(ctx context.Context) (done bool, err error) {
 		return func(ctx context.Context) (done bool, err error) {
 			// we don't know when CRD would be first created, so we should
-			// look up GVK whenever a new wait condition is required
-			gvk, err := kindToGVK(wc.Kind, c.discoveryClient)
+			// look up GVK whenever a new wait condition is required;
+			// discovery is cached and refreshed only if the kind is missing
+			gvk, err := c.kindToGVK(wc.Kind)
 			if err != nil {
 				return false, err
 			}
@@ -177,8 +208,9 @@ func (wc *WaitCondition) statusCustom() {
 	wc.condF = func(c *Client) func(ctx context.Context) (done bool, err error) {
 		return func(ctx context.Context) (done bool, err error) {
 			// we don't know when CRD would be first created, so we should
-			// look up GVK whenever a new wait condition is required
-			gvk, err := kindToGVK(wc.Kind, c.discoveryClient)
+			// look up GVK whenever a new wait condition is required;
+			// discovery is cached and refreshed only if the kind is missing
+			gvk, err := c.kindToGVK(wc.Kind)
 			if err != nil {
 				return false, err
 			}
@@ -223,18 +255,22 @@ func (wc *WaitCondition) statusCustom() {
 func (wc *WaitCondition) event() {
 	wc.condF = func(c *Client) func(ctx context.Context) (done bool, err error) {
 		return func(ctx context.Context) (done bool, err error) {
-			events, err := c.clientset.CoreV1().Events(wc.Namespace).List(ctx, metav1.ListOptions{
-				TypeMeta: metav1.TypeMeta{
-					Kind: wc.Kind,
-				},
-				FieldSelector: "involvedObject.name=" + wc.Name,
-			})
+			// namespace of wait condition is mapped already
+			events, err := c.dynamicClient.Resource(corev1.SchemeGroupVersion.WithResource("events")).
+				Namespace(wc.Namespace).
+				List(ctx, metav1.ListOptions{FieldSelector: "involvedObject.name=" + wc.Name})
 			if err != nil {
 				return
 			}
 
-			for _, event := range events.Items {
-				if event.Reason == wc.Reason {
+			for _, item := range events.Items {
+				var event corev1.Event
+				if err = runtime.DefaultUnstructuredConverter.FromUnstructured(item.Object, &event); err != nil {
+					return
+				}
+
+				// field selector is not supported by every client, e.g. the fake one
+				if event.InvolvedObject.Name == wc.Name && event.Reason == wc.Reason {
 					done = true
 					return
 				}
@@ -0,0 +1,46 @@
+// Package kwok provides an implementation of environment that runs a local
+// kwok control plane with simulated nodes and pods.
+package kwok
+
+import (
+	"os/exec"
+	"strings"
+
+	"github.com/grafana/xk6-environment/pkg/command"
+)
+
+// Create creates a kwok cluster with the given name and
+// waits until it is ready.
+func Create(name string) error {
+	// empty --kubeconfig stops kwokctl from adding context
+	// of the cluster to Kubeconfig of the user
+	cmd := exec.Command("kwokctl", "create", "cluster", "--name", name, "--wait", "5m", "--kubeconfig", "") // #nosec G204
+
+	return command.Run(cmd, nil)
+}
+
+// Delete removes the kwok cluster with the given name.
+func Delete(name string) error {
+	cmd := exec.Command("kwokctl", "delete", "cluster", "--name", name, "--kubeconfig", "") // #nosec G204
+
+	return command.Run(cmd, nil)
+}
+
+// Kubeconfig returns kubeconfig of the kwok cluster with the given name.
+func Kubeconfig(name string) ([]byte, error) {
+	cmd := exec.Command("kwokctl", "get", "kubeconfig", "--name", name) // #nosec G204
+
+	return command.Output(cmd)
+}
+
+// List returns names of existing kwok clusters.
+func List() ([]string, error) {
+	cmd := exec.Command("kwokctl", "get", "clusters")
+
+	out, err := command.Output(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	return strings.Fields(string(out)), nil
+}
@@ -0,0 +1,177 @@
+package kwok
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/grafana/xk6-environment/pkg/kubernetes"
+	"github.com/grafana/xk6-environment/pkg/provider"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+// NodeAnnotation marks nodes managed by kwok.
+const NodeAnnotation = "kwok.x-k8s.io/node"
+
+// nodes describes simulated nodes of kwok cluster.
+type nodes struct {
+	count             int
+	cpu, memory, pods resource.Quantity
+}
+
+// Provider creates environment as a local kwok cluster with simulated
+// nodes. It requires kwokctl CLI.
+type Provider struct {
+	name       string
+	configPath string
+	nodes      nodes
+}
+
+var (
+	_ provider.Provider         = (*Provider)(nil)
+	_ provider.RESTConfigGetter = (*Provider)(nil)
+)
+
+// NewProvider constructs a kwok Provider. Simulated nodes can be configured
+// with "kwok" object in parameters of Environment:
+//
+//	kwok: { nodes: 100, cpu: "32", memory: "256Gi", pods: 110 }
+func NewProvider(params provider.Params) (provider.Provider, error) {
+	p := &Provider{
+		name:       params.Name,
+		configPath: params.ConfigPath,
+		nodes: nodes{
+			count:  1,
+			cpu:    resource.MustParse("32"),
+			memory: resource.MustParse("256Gi"),
+			pods:   resource.MustParse("110"),
+		},
+	}
+
+	if opts, ok := params.Options["kwok"]; ok {
+		if err := p.nodes.parse(opts); err != nil {
+			return nil, err
+		}
+	}
+
+	return p, nil
+}
+
+func (n *nodes) parse(opts interface{}) (err error) {
+	e := fmt.Errorf(`"kwok" must be an object of the form {nodes:100,cpu:"32",memory:"256Gi",pods:110}; got: %+v`, opts)
+	m, ok := opts.(map[string]interface{})
+	if !ok {
+		return e
+	}
+
+	if v, ok := m["nodes"]; ok {
+		switch count := v.(type) {
+		case int64:
+			n.count = int(count)
+		case float64:
+			n.count = int(count)
+		default:
+			return e
+		}
+	}
+
+	for key, q := range map[string]*resource.Quantity{"cpu": &n.cpu, "memory": &n.memory, "pods": &n.pods} {
+		v, ok := m[key]
+		if !ok {
+			continue
+		}
+		if *q, err = resource.ParseQuantity(fmt.Sprint(v)); err != nil {
+			return fmt.Errorf(`invalid %s of kwok nodes: %w`, key, err)
+		}
+	}
+
+	return nil
+}
+
+// Create creates the kwok cluster and registers simulated nodes in it.
+func (p *Provider) Create(ctx context.Context) error {
+	if err := Create(p.name); err != nil {
+		return err
+	}
+
+	restConfig, err := p.RESTConfig(ctx)
+	if err != nil {
+		return err
+	}
+
+	c, err := kubernetes.NewClientForConfig(ctx, restConfig)
+	if err != nil {
+		return err
+	}
+
+	for i := 0; i < p.nodes.count; i++ {
+		if err := c.CreateNode(ctx, p.node(i)); err != nil {
+			return fmt.Errorf("unable to create kwok node: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (p *Provider) node(i int) *corev1.Node {
+	name := fmt.Sprintf("kwok-node-%d", i)
+	resources := corev1.ResourceList{
+		corev1.ResourceCPU:    p.nodes.cpu,
+		corev1.ResourceMemory: p.nodes.memory,
+		corev1.ResourcePods:   p.nodes.pods,
+	}
+
+	return &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+			Annotations: map[string]string{
+				NodeAnnotation: "fake",
+			},
+			Labels: map[string]string{
+				"type":                   "kwok",
+				"kubernetes.io/hostname": name,
+				"kubernetes.io/role":     "agent",
+			},
+		},
+		Status: corev1.NodeStatus{
+			Allocatable: resources,
+			Capacity:    resources,
+			Phase:       corev1.NodeRunning,
+		},
+	}
+}
+
+// Delete removes the kwok cluster.
+func (p *Provider) Delete(_ context.Context) error {
+	return Delete(p.name)
+}
+
+// RESTConfig returns configuration to access the kwok cluster, built
+// from the kubeconfig reported by kwokctl CLI.
+func (p *Provider) RESTConfig(_ context.Context) (*rest.Config, error) {
+	kubeconfig, err := Kubeconfig(p.name)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get kubeconfig of kwok cluster: %w", err)
+	}
+
+	return clientcmd.RESTConfigFromKubeConfig(kubeconfig)
+}
+
+// Status returns the state of kwok cluster as reported by kwokctl CLI.
+func (p *Provider) Status(_ context.Context) (provider.Status, error) {
+	clusters, err := List()
+	if err != nil {
+		return provider.StatusUnknown, err
+	}
+
+	if slices.Contains(clusters, p.name) {
+		return provider.StatusRunning, nil
+	}
+
+	return provider.StatusNotFound, nil
+}
@@ -0,0 +1,43 @@
+package kwok
+
+import (
+	"testing"
+
+	"github.com/grafana/xk6-environment/pkg/provider"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+func Test_NewProvider(t *testing.T) {
+	t.Parallel()
+
+	p, err := NewProvider(provider.Params{
+		Name: "test",
+		Options: map[string]interface{}{
+			"kwok": map[string]interface{}{
+				"nodes":  int64(100),
+				"cpu":    "4",
+				"memory": "16Gi",
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	kp, ok := p.(*Provider)
+	require.True(t, ok)
+	assert.Equal(t, 100, kp.nodes.count)
+	assert.True(t, resource.MustParse("4").Equal(kp.nodes.cpu))
+	assert.True(t, resource.MustParse("16Gi").Equal(kp.nodes.memory))
+	assert.True(t, resource.MustParse("110").Equal(kp.nodes.pods))
+
+	_, err = NewProvider(provider.Params{
+		Name: "test",
+		Options: map[string]interface{}{
+			"kwok": map[string]interface{}{
+				"cpu": "lots",
+			},
+		},
+	})
+	assert.Error(t, err)
+}
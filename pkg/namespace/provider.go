@@ -0,0 +1,92 @@
+// Package namespace provides an implementation of environment that
+// is isolated by namespaces within the cluster of the current context.
+package namespace
+
+import (
+	"context"
+
+	"github.com/grafana/xk6-environment/pkg/kubernetes"
+	"github.com/grafana/xk6-environment/pkg/provider"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// Provider creates environment as a namespace in the current context.
+// Objects from the "default" or empty namespace are put into a namespace
+// named after the environment; objects from any other namespace are put
+// into a namespace prefixed by the name of environment.
+type Provider struct {
+	name       string
+	configPath string
+}
+
+var (
+	_ provider.Provider          = (*Provider)(nil)
+	_ kubernetes.NamespaceMapper = (*Provider)(nil)
+)
+
+// NewProvider constructs a namespace Provider.
+func NewProvider(params provider.Params) (provider.Provider, error) {
+	return &Provider{
+		name:       params.Name,
+		configPath: params.ConfigPath,
+	}, nil
+}
+
+// Create creates the main namespace of the environment.
+func (p *Provider) Create(ctx context.Context) error {
+	c, err := kubernetes.NewClient(ctx, p.configPath)
+	if err != nil {
+		return err
+	}
+
+	return c.EnsureNamespace(ctx, p.name, p.NamespaceLabels())
+}
+
+// Delete removes all namespaces of the environment together with
+// everything in them.
+func (p *Provider) Delete(ctx context.Context) error {
+	c, err := kubernetes.NewClient(ctx, p.configPath)
+	if err != nil {
+		return err
+	}
+
+	return c.DeleteNamespaces(ctx, p.NamespaceLabels())
+}
+
+// Status returns the state of the main namespace of the environment.
+func (p *Provider) Status(ctx context.Context) (provider.Status, error) {
+	c, err := kubernetes.NewClient(ctx, p.configPath)
+	if err != nil {
+		return provider.StatusUnknown, err
+	}
+
+	phase, found, err := c.NamespacePhase(ctx, p.name)
+	switch {
+	case err != nil:
+		return provider.StatusUnknown, err
+	case !found:
+		return provider.StatusNotFound, nil
+	case phase == corev1.NamespaceActive:
+		return provider.StatusRunning, nil
+	default:
+		return provider.StatusPending, nil
+	}
+}
+
+// MapNamespace returns the namespace of the environment which corresponds
+// to the given namespace.
+func (p *Provider) MapNamespace(namespace string) string {
+	if len(namespace) == 0 || namespace == "default" {
+		return p.name
+	}
+
+	return p.name + "-" + namespace
+}
+
+// NamespaceLabels returns labels that mark namespaces of the environment.
+func (p *Provider) NamespaceLabels() map[string]string {
+	return map[string]string{
+		kubernetes.EnvironmentLabel: p.name,
+	}
+}
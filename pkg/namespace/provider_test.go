@@ -0,0 +1,42 @@
+package namespace
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_MapNamespace(t *testing.T) {
+	testCases := []struct {
+		name      string
+		namespace string
+		expected  string
+	}{
+		{
+			"empty namespace is mapped to environment namespace",
+			"",
+			"env",
+		},
+		{
+			"default namespace is mapped to environment namespace",
+			"default",
+			"env",
+		},
+		{
+			"other namespace is prefixed",
+			"monitoring",
+			"env-monitoring",
+		},
+	}
+
+	p := &Provider{name: "env"}
+
+	t.Parallel()
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, testCase.expected, p.MapNamespace(testCase.namespace))
+		})
+	}
+}
@@ -0,0 +1,84 @@
+// Package provider defines the contract between Environment and
+// the implementations that bring up an isolated Kubernetes environment.
+package provider
+
+import (
+	"context"
+
+	"github.com/grafana/xk6-environment/pkg/fs"
+	"github.com/grafana/xk6-environment/pkg/kubernetes"
+
+	"github.com/sirupsen/logrus"
+	"k8s.io/client-go/rest"
+)
+
+// Status describes the state of an environment, as reported by its Provider.
+type Status string
+
+const (
+	// StatusUnknown means that Provider cannot determine the state of environment.
+	StatusUnknown Status = "unknown"
+	// StatusNotFound means that environment does not exist.
+	StatusNotFound Status = "not found"
+	// StatusPending means that environment exists but is not ready yet.
+	StatusPending Status = "pending"
+	// StatusRunning means that environment is ready to be used.
+	StatusRunning Status = "running"
+)
+
+// Params holds configuration of the environment, passed on to
+// the Provider on its construction.
+type Params struct {
+	// Name is the name of the environment.
+	Name string
+	// ConfigPath is the path to Kubeconfig. If it's empty, Kubeconfig is
+	// looked up with KUBECONFIG environment variable or at ~/.kube/config.
+	ConfigPath string
+	// Options are the parameters of Environment constructor,
+	// as specified by a user in the script. Providers may
+	// look up their own configuration here.
+	Options map[string]interface{}
+	// Env describes the content of init folder; it can be nil.
+	Env *fs.EnvDescription
+	// Logger is the k6 logger; it can be nil.
+	Logger logrus.FieldLogger
+}
+
+// Provider is an implementation of environment lifecycle. By default,
+// the environment is accessed with the current context of Kubeconfig;
+// providers of other environments implement RESTConfigGetter or
+// ClientGetter. Providers must never modify Kubeconfig of the user.
+type Provider interface {
+	// Create brings up the environment.
+	Create(ctx context.Context) error
+	// Delete removes the environment.
+	Delete(ctx context.Context) error
+	// Status returns the current state of the environment.
+	Status(ctx context.Context) (Status, error)
+}
+
+// Factory constructs a Provider.
+type Factory func(params Params) (Provider, error)
+
+// RESTConfigGetter is implemented by providers which give access to
+// the environment with a configuration of their own, e.g. one that is
+// kept in memory.
+type RESTConfigGetter interface {
+	// RESTConfig returns configuration to access the environment.
+	RESTConfig(ctx context.Context) (*rest.Config, error)
+}
+
+// ConnectionCloser is implemented by providers of RESTConfigGetter which reach
+// the environment through a connection that may close, e.g. a tunnel to a pod.
+type ConnectionCloser interface {
+	// Closed returns a channel which is closed when the connection used by
+	// the given configuration, as returned by RESTConfig, is closed.
+	Closed(restConfig *rest.Config) <-chan struct{}
+}
+
+// ClientGetter is implemented by providers which construct Kubernetes
+// client on their own, e.g. without access to any cluster.
+type ClientGetter interface {
+	// Client returns the client to access the environment.
+	Client(ctx context.Context) (*kubernetes.Client, error)
+}
@@ -0,0 +1,127 @@
+package vcluster
+
+import (
+	"fmt"
+
+	"helm.sh/helm/v3/pkg/chartutil"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// defaultDistro is the Kubernetes distribution of vcluster chart, used
+// when only the version of Kubernetes is given.
+const defaultDistro = "k8s"
+
+// Config holds configuration of vcluster, as given by "vcluster" object
+// in parameters of Environment:
+//
+//	vcluster: {
+//	  chart: "vcluster-0.20.0.tgz",
+//	  chartRepository: "https://charts.loft.sh",
+//	  chartVersion: "0.20.0",
+//	  valuesFile: "values.yaml",
+//	  values: { sync: { toHost: { ingresses: { enabled: true } } } },
+//	  kubernetesVersion: "v1.29.0",
+//	  distro: "k8s",
+//	  cli: false,
+//	}
+type Config struct {
+	// Chart is the path to a local vcluster chart. If it's empty, the chart
+	// is downloaded from ChartRepository.
+	Chart string
+	// ChartRepository is the URL of Helm repository to download vcluster chart
+	// from. It must be given explicitly unless Chart is set.
+	ChartRepository string
+	// ChartVersion is the version of the chart in ChartRepository;
+	// the latest version is installed by default.
+	ChartVersion string
+	// ValuesFile is the path to a file with values of vcluster chart.
+	ValuesFile string
+	// Values are values of vcluster chart; they override ValuesFile.
+	Values map[string]interface{}
+	// KubernetesVersion is the version of Kubernetes within vcluster.
+	KubernetesVersion string
+	// Distro is the Kubernetes distribution within vcluster, e.g. k3s or k8s.
+	Distro string
+	// CLI makes vcluster to be created with vcluster CLI instead of Helm.
+	//
+	// Deprecated: vcluster CLI is supported only for compatibility with
+	// previous versions; it's going to be removed.
+	CLI bool
+}
+
+// ParseConfig constructs Config from "vcluster" object of Environment parameters.
+func ParseConfig(opts interface{}) (cfg Config, err error) {
+	m, ok := opts.(map[string]interface{})
+	if !ok {
+		err = fmt.Errorf(`"vcluster" must be an object of the form `+
+			`{chart: "path", chartRepository: "url", chartVersion: "0.20.0", valuesFile: "path", values: {}, `+
+			`kubernetesVersion: "v1.29.0", distro: "k8s"}; got: %+v`, opts)
+		return
+	}
+
+	cfg.Chart, _ = m["chart"].(string)
+	cfg.ChartRepository, _ = m["chartRepository"].(string)
+	cfg.ChartVersion, _ = m["chartVersion"].(string)
+	cfg.ValuesFile, _ = m["valuesFile"].(string)
+	cfg.KubernetesVersion, _ = m["kubernetesVersion"].(string)
+	cfg.Distro, _ = m["distro"].(string)
+
+	if v, ok := m["values"]; ok {
+		if cfg.Values, ok = v.(map[string]interface{}); !ok {
+			err = fmt.Errorf(`"values" of vcluster must be an object; got: %+v`, v)
+			return
+		}
+	}
+
+	if v, ok := m["cli"]; ok {
+		if cfg.CLI, ok = v.(bool); !ok {
+			err = fmt.Errorf(`"cli" of vcluster must be a boolean; got: %+v`, v)
+		}
+	}
+
+	return
+}
+
+// chartValues returns values for vcluster chart, combined from ValuesFile,
+// Distro, KubernetesVersion and Values, in the increasing order of precedence.
+//
+// Distro and KubernetesVersion are set as controlPlane.distro.<distro>.enabled
+// and controlPlane.distro.<distro>.image.tag, which is the layout of values
+// since vcluster v0.20. Note that the tag depends on distro, e.g. "v1.29.0"
+// for k8s but "v1.29.0-k3s1" for k3s.
+func (cfg Config) chartValues() (map[string]interface{}, error) {
+	// MergeTables shares nested maps, which must not be changed in Config
+	values := map[string]interface{}{}
+	if cfg.Values != nil {
+		values = runtime.DeepCopyJSON(cfg.Values)
+	}
+
+	if len(cfg.Distro) > 0 || len(cfg.KubernetesVersion) > 0 {
+		distroName := cfg.Distro
+		if len(distroName) == 0 {
+			distroName = defaultDistro
+		}
+
+		distro := map[string]interface{}{"enabled": true}
+		if len(cfg.KubernetesVersion) > 0 {
+			distro["image"] = map[string]interface{}{"tag": cfg.KubernetesVersion}
+		}
+
+		chartutil.MergeTables(values, map[string]interface{}{
+			"controlPlane": map[string]interface{}{
+				"distro": map[string]interface{}{distroName: distro},
+			},
+		})
+	}
+
+	if len(cfg.ValuesFile) > 0 {
+		fromFile, err := chartutil.ReadValuesFile(cfg.ValuesFile)
+		if err != nil {
+			return nil, fmt.Errorf("cannot read vcluster values from %s: %w", cfg.ValuesFile, err)
+		}
+
+		chartutil.MergeTables(values, fromFile)
+	}
+
+	return values, nil
+}
@@ -0,0 +1,104 @@
+package vcluster
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_chartValues(t *testing.T) {
+	t.Parallel()
+
+	valuesFile := filepath.Join(t.TempDir(), "vcluster.yaml")
+	//nolint:forbidigo
+	require.NoError(t, os.WriteFile(valuesFile, []byte("sync:\n  toHost:\n    ingresses:\n      enabled: false\nexperimental: {}\n"), 0o600))
+
+	values, err := Config{
+		ValuesFile:        valuesFile,
+		Values:            map[string]interface{}{"sync": map[string]interface{}{"toHost": map[string]interface{}{"ingresses": map[string]interface{}{"enabled": true}}}},
+		KubernetesVersion: "v1.29.0",
+	}.chartValues()
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]interface{}{
+		"sync":         map[string]interface{}{"toHost": map[string]interface{}{"ingresses": map[string]interface{}{"enabled": true}}},
+		"experimental": map[string]interface{}{},
+		"controlPlane": map[string]interface{}{"distro": map[string]interface{}{
+			"k8s": map[string]interface{}{"enabled": true, "image": map[string]interface{}{"tag": "v1.29.0"}},
+		}},
+	}, values)
+}
+
+func Test_chartValuesKeepConfig(t *testing.T) {
+	t.Parallel()
+
+	valuesFile := filepath.Join(t.TempDir(), "vcluster.yaml")
+	//nolint:forbidigo
+	require.NoError(t, os.WriteFile(valuesFile, []byte("sync:\n  toHost:\n    services:\n      enabled: true\n"), 0o600))
+
+	cfg := Config{
+		ValuesFile: valuesFile,
+		Values: map[string]interface{}{
+			"sync":         map[string]interface{}{"toHost": map[string]interface{}{}},
+			"controlPlane": map[string]interface{}{"distro": map[string]interface{}{}},
+		},
+		KubernetesVersion: "v1.29.0",
+	}
+
+	for range 2 {
+		_, err := cfg.chartValues()
+		require.NoError(t, err)
+	}
+
+	// nested values are merged into a copy
+	assert.Equal(t, map[string]interface{}{
+		"sync":         map[string]interface{}{"toHost": map[string]interface{}{}},
+		"controlPlane": map[string]interface{}{"distro": map[string]interface{}{}},
+	}, cfg.Values)
+}
+
+func Test_ParseConfig(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name    string
+		opts    interface{}
+		want    Config
+		wantErr bool
+	}{
+		{
+			name: "all fields",
+			opts: map[string]interface{}{
+				"chart": "vcluster.tgz", "chartRepository": "https://charts.loft.sh", "chartVersion": "0.20.0",
+				"valuesFile": "values.yaml", "values": map[string]interface{}{"a": 1},
+				"kubernetesVersion": "v1.29.0", "distro": "k3s", "cli": true,
+			},
+			want: Config{
+				Chart: "vcluster.tgz", ChartRepository: "https://charts.loft.sh", ChartVersion: "0.20.0",
+				ValuesFile: "values.yaml", Values: map[string]interface{}{"a": 1},
+				KubernetesVersion: "v1.29.0", Distro: "k3s", CLI: true,
+			},
+		},
+		{name: "not an object", opts: "vcluster.tgz", wantErr: true},
+		{name: "values not an object", opts: map[string]interface{}{"values": "a: 1"}, wantErr: true},
+		{name: "cli not a boolean", opts: map[string]interface{}{"cli": "yes"}, wantErr: true},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			cfg, err := ParseConfig(testCase.opts)
+			if testCase.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, testCase.want, cfg)
+		})
+	}
+}
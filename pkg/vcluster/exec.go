@@ -2,28 +2,127 @@
 package vcluster
 
 import (
+	"encoding/json"
 	"fmt"
+	"os"
 	"os/exec"
+
+	"github.com/grafana/xk6-environment/pkg/command"
+
+	"github.com/sirupsen/logrus"
+	"sigs.k8s.io/yaml"
 )
 
-// Temporary! Replace with Helm chart deployment.
+// CommandError is returned when a command of vcluster CLI fails.
+type CommandError = command.Error
 
-// Create creates a vcluster with the given name.
-func Create(name string) error {
-	// This command connects by default; without connection, vcluster doesn't create kubectl context
-	// Flags checked and removed: "--update-current=true", "--connect=false")
-	cmd := exec.Command("vcluster", "create", name, fmt.Sprintf("--kube-config-context-name=%s", name)) // #nosec G204
+// The functions below wrap vcluster CLI. See HelmProvider
+// for creation of vcluster without the CLI.
 
-	_, err := cmd.Output()
-	return err
+// vclusterCommand returns the command of vcluster CLI with the given arguments.
+// vcluster CLI accesses the host cluster with Kubeconfig at configPath or,
+// if it's empty, with KUBECONFIG inherited from k6 process.
+func vclusterCommand(configPath string, args ...string) *exec.Cmd {
+	cmd := exec.Command("vcluster", args...) // #nosec G204
+	if len(configPath) > 0 {
+		//nolint:forbidigo
+		cmd.Env = append(os.Environ(), "KUBECONFIG="+configPath)
+	}
+
+	return cmd
 }
 
-// Delete removes the vcluster with the given name.
-func Delete(name string) error {
+// Create creates a vcluster with the given name and configuration. Progress
+// of creation is logged to logger, if it's not nil.
+func Create(configPath, name string, cfg Config, logger logrus.FieldLogger) error {
+	// vcluster CLI connects by default, which adds a context to Kubeconfig
+	// and switches to it; vcluster is accessed with its kubeconfig secret instead
+	args := []string{"create", name, "--connect=false"}
+
+	if len(cfg.Distro) > 0 {
+		args = append(args, "--distro", cfg.Distro)
+	}
+
+	if len(cfg.KubernetesVersion) > 0 {
+		args = append(args, "--kubernetes-version", cfg.KubernetesVersion)
+	}
+
+	if len(cfg.ValuesFile) > 0 {
+		args = append(args, "--values", cfg.ValuesFile)
+	}
+
+	// inline values are passed as one more values file
+	// which takes precedence over the previous one
+	if len(cfg.Values) > 0 {
+		valuesFile, err := writeValues(cfg.Values)
+		if err != nil {
+			return err
+		}
+		//nolint:forbidigo
+		defer os.Remove(valuesFile) //nolint:errcheck
+
+		args = append(args, "--values", valuesFile)
+	}
+
+	cmd := vclusterCommand(configPath, args...)
+
+	return command.Run(cmd, logger)
+}
+
+// writeValues stores values in a temporary file and returns its path.
+func writeValues(values map[string]interface{}) (string, error) {
+	data, err := yaml.Marshal(values)
+	if err != nil {
+		return "", err
+	}
+
+	//nolint:forbidigo
+	f, err := os.CreateTemp("", "vcluster-values-*.yaml")
+	if err != nil {
+		return "", err
+	}
+	defer f.Close() //nolint:errcheck
+
+	if _, err := f.Write(data); err != nil {
+		return "", err
+	}
+
+	return f.Name(), nil
+}
+
+// Delete removes the vcluster with the given name. Progress of removal
+// is logged to logger, if it's not nil.
+func Delete(configPath, name string, logger logrus.FieldLogger) error {
 	// vcluster disconnect won't work here;
 	// probably because we connected "manually"
-	cmd := exec.Command("vcluster", "delete", name)
+	cmd := vclusterCommand(configPath, "delete", name)
+
+	return command.Run(cmd, logger)
+}
+
+type listItem struct {
+	Name   string
+	Status string
+}
+
+// List returns statuses of existing vclusters, keyed by their name.
+func List(configPath string) (map[string]string, error) {
+	cmd := vclusterCommand(configPath, "list", "--output", "json")
+
+	out, err := command.Output(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	var items []listItem
+	if err = json.Unmarshal(out, &items); err != nil {
+		return nil, fmt.Errorf("unable to parse output of vcluster list: %w", err)
+	}
+
+	statuses := make(map[string]string, len(items))
+	for _, item := range items {
+		statuses[item.Name] = item.Status
+	}
 
-	_, err := cmd.Output()
-	return err
+	return statuses, nil
 }
@@ -0,0 +1,145 @@
+package vcluster
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"helm.sh/helm/v3/pkg/action"
+	"helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/chart/loader"
+	"helm.sh/helm/v3/pkg/cli"
+	"helm.sh/helm/v3/pkg/release"
+	"helm.sh/helm/v3/pkg/storage/driver"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+// helmTimeout is how long to wait for vcluster to become ready
+// or to be removed.
+const helmTimeout = 5 * time.Minute
+
+// errNoChart is returned when neither a local chart nor a chart repository is given:
+// the chart is never downloaded implicitly.
+var errNoChart = errors.New(`vcluster chart is required: set "chart" to a local chart archive or directory, ` +
+	`or "chartRepository" to download it, e.g. "https://charts.loft.sh"`)
+
+// chartName is the name of vcluster chart in a chart repository,
+// e.g. https://charts.loft.sh.
+const chartName = "vcluster"
+
+// Namespace returns the namespace in the host cluster where the vcluster
+// with the given name is installed. This follows convention of vcluster CLI.
+func Namespace(name string) string {
+	return "vcluster-" + name
+}
+
+func helmConfig(configPath, namespace string) (*action.Configuration, error) {
+	flags := genericclioptions.NewConfigFlags(false)
+	if len(configPath) > 0 {
+		flags.KubeConfig = &configPath
+	}
+	flags.Namespace = &namespace
+
+	cfg := new(action.Configuration)
+	if err := cfg.Init(flags, namespace, "secret", func(string, ...interface{}) {}); err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
+}
+
+// Install installs vcluster chart as a release with the given name and waits until
+// the vcluster is ready. The chart is either a local chart archive or directory given
+// by Config.Chart or, only if Config.ChartRepository is set, it's downloaded from that
+// repository.
+func Install(ctx context.Context, configPath, name string, chartCfg Config, values map[string]interface{}) error {
+	cfg, err := helmConfig(configPath, Namespace(name))
+	if err != nil {
+		return err
+	}
+
+	return install(ctx, cfg, name, chartCfg, values)
+}
+
+func install(
+	ctx context.Context, cfg *action.Configuration, name string, chartCfg Config, values map[string]interface{},
+) error {
+	install := action.NewInstall(cfg)
+	install.ReleaseName = name
+	install.Namespace = Namespace(name)
+	install.CreateNamespace = true
+	install.Wait = true
+	install.Timeout = helmTimeout
+
+	vclusterChart, err := locateChart(&install.ChartPathOptions, chartCfg)
+	if err != nil {
+		return err
+	}
+
+	_, err = install.RunWithContext(ctx, vclusterChart, values)
+	return err
+}
+
+// locateChart loads the local chart given by Config.Chart or, if it's empty,
+// downloads vcluster chart from Config.ChartRepository.
+func locateChart(opts *action.ChartPathOptions, cfg Config) (*chart.Chart, error) {
+	chartPath := cfg.Chart
+	if len(chartPath) == 0 {
+		if len(cfg.ChartRepository) == 0 {
+			return nil, errNoChart
+		}
+
+		opts.RepoURL = cfg.ChartRepository
+		opts.Version = cfg.ChartVersion
+		chartPath = chartName
+	}
+
+	path, err := opts.LocateChart(chartPath, cli.New())
+	if err != nil {
+		return nil, err
+	}
+
+	return loader.Load(path)
+}
+
+// Uninstall removes the release of vcluster with the given name.
+func Uninstall(configPath, name string) error {
+	cfg, err := helmConfig(configPath, Namespace(name))
+	if err != nil {
+		return err
+	}
+
+	return uninstall(cfg, name)
+}
+
+func uninstall(cfg *action.Configuration, name string) error {
+	uninstall := action.NewUninstall(cfg)
+	uninstall.Wait = true
+	uninstall.Timeout = helmTimeout
+
+	_, err := uninstall.Run(name)
+	return err
+}
+
+// ReleaseStatus returns the status of the release of vcluster with the given name.
+// If there is no such release, found is false.
+func ReleaseStatus(configPath, name string) (status release.Status, found bool, err error) {
+	cfg, err := helmConfig(configPath, Namespace(name))
+	if err != nil {
+		return "", false, err
+	}
+
+	return releaseStatus(cfg, name)
+}
+
+func releaseStatus(cfg *action.Configuration, name string) (status release.Status, found bool, err error) {
+	rel, err := action.NewStatus(cfg).Run(name)
+	if errors.Is(err, driver.ErrReleaseNotFound) {
+		return "", false, nil
+	}
+	if err != nil {
+		return "", false, err
+	}
+
+	return rel.Info.Status, true, nil
+}
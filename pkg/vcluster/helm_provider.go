@@ -0,0 +1,193 @@
+package vcluster
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/grafana/xk6-environment/pkg/kubernetes"
+	"github.com/grafana/xk6-environment/pkg/provider"
+
+	"helm.sh/helm/v3/pkg/release"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+// port of vcluster API server within the vcluster pod
+const apiServerPort = 8443
+
+// Tunnels to vcluster API server are opened in one k6 VU and used or
+// closed in others, so they are kept per process, keyed by the name of vcluster.
+//
+//nolint:gochecknoglobals
+var (
+	mu      sync.Mutex
+	tunnels = map[string]*kubernetes.PortForward{}
+)
+
+// HelmProvider creates environment as a vcluster installed with Helm,
+// from a local chart or from the given chart repository. It requires nothing but
+// access to the host cluster.
+type HelmProvider struct {
+	name       string
+	configPath string
+	config     Config
+}
+
+var (
+	_ provider.Provider         = (*HelmProvider)(nil)
+	_ provider.RESTConfigGetter = (*HelmProvider)(nil)
+	_ provider.ConnectionCloser = (*HelmProvider)(nil)
+)
+
+// Create installs the vcluster chart and waits until vcluster is ready.
+func (p *HelmProvider) Create(ctx context.Context) error {
+	values, err := p.config.chartValues()
+	if err != nil {
+		return err
+	}
+
+	return Install(ctx, p.configPath, p.name, p.config, values)
+}
+
+// Delete closes the tunnel to vcluster, uninstalls the chart and removes
+// the namespace of vcluster.
+func (p *HelmProvider) Delete(ctx context.Context) error {
+	closeTunnel(p.name)
+
+	if err := Uninstall(p.configPath, p.name); err != nil {
+		return err
+	}
+
+	host, err := kubernetes.NewClient(ctx, p.configPath)
+	if err != nil {
+		return err
+	}
+
+	return host.DeleteNamespace(ctx, Namespace(p.name))
+}
+
+// Status returns the state of vcluster as reported by Helm.
+func (p *HelmProvider) Status(_ context.Context) (provider.Status, error) {
+	status, found, err := ReleaseStatus(p.configPath, p.name)
+	switch {
+	case err != nil:
+		return provider.StatusUnknown, err
+	case !found:
+		return provider.StatusNotFound, nil
+	case status == release.StatusDeployed:
+		return provider.StatusRunning, nil
+	case status.IsPending():
+		return provider.StatusPending, nil
+	default:
+		return provider.StatusUnknown, nil
+	}
+}
+
+// RESTConfig returns configuration to access vcluster.
+func (p *HelmProvider) RESTConfig(ctx context.Context) (*rest.Config, error) {
+	return restConfig(ctx, p.configPath, p.name)
+}
+
+// Closed returns a channel which is closed when the tunnel to vcluster,
+// used by the given configuration, is closed.
+func (p *HelmProvider) Closed(restConfig *rest.Config) <-chan struct{} {
+	return tunnelClosed(p.name, restConfig)
+}
+
+// restConfig returns configuration to access vcluster with the given name,
+// built from the kubeconfig secret generated by vcluster. vcluster API server
+// is reached through a tunnel to the vcluster pod. It is the same for vclusters
+// created with Helm or vcluster CLI, so that kubeconfig is kept in memory and
+// no Kubernetes context is needed.
+func restConfig(ctx context.Context, configPath, name string) (*rest.Config, error) {
+	host, err := kubernetes.NewClient(ctx, configPath)
+	if err != nil {
+		return nil, err
+	}
+
+	restConfig, err := kubeconfig(ctx, host, name)
+	if err != nil {
+		return nil, err
+	}
+
+	pf, err := tunnel(ctx, host, name)
+	if err != nil {
+		return nil, err
+	}
+
+	restConfig.Host = tunnelHost(pf)
+	return restConfig, nil
+}
+
+// tunnelHost returns the address of vcluster API server through the tunnel.
+func tunnelHost(pf *kubernetes.PortForward) string {
+	// certificate of vcluster API server is valid for localhost
+	return fmt.Sprintf("https://localhost:%d", pf.LocalPort)
+}
+
+// kubeconfig returns configuration from the kubeconfig secret generated by
+// vcluster with the given name; its API server is not reachable as is.
+func kubeconfig(ctx context.Context, host *kubernetes.Client, name string) (*rest.Config, error) {
+	data, err := host.SecretData(ctx, Namespace(name), "vc-"+name, "config")
+	if err != nil {
+		return nil, fmt.Errorf("unable to get kubeconfig of vcluster: %w", err)
+	}
+
+	return clientcmd.RESTConfigFromKubeConfig(data)
+}
+
+// tunnel returns an open tunnel to API server of vcluster with the given name,
+// opening it if needed.
+func tunnel(ctx context.Context, host *kubernetes.Client, name string) (*kubernetes.PortForward, error) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if pf, ok := tunnels[name]; ok {
+		select {
+		case <-pf.Done():
+		default:
+			return pf, nil
+		}
+	}
+
+	pod, err := host.ReadyPod(ctx, Namespace(name), "app=vcluster,release="+name)
+	if err != nil {
+		return nil, err
+	}
+
+	pf, err := host.ForwardPort(Namespace(name), pod, apiServerPort)
+	if err != nil {
+		return nil, err
+	}
+
+	tunnels[name] = pf
+	return pf, nil
+}
+
+// tunnelClosed returns a channel which is closed when the tunnel used by the
+// given configuration of vcluster with the given name is closed.
+func tunnelClosed(name string, restConfig *rest.Config) <-chan struct{} {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if pf, ok := tunnels[name]; ok && restConfig.Host == tunnelHost(pf) {
+		return pf.Done()
+	}
+
+	// the tunnel was closed and removed, or replaced by another one
+	closed := make(chan struct{})
+	close(closed)
+	return closed
+}
+
+// closeTunnel closes the tunnel to vcluster with the given name, if any.
+func closeTunnel(name string) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if pf, ok := tunnels[name]; ok {
+		pf.Close()
+		delete(tunnels, name)
+	}
+}
@@ -0,0 +1,82 @@
+package vcluster
+
+import (
+	"context"
+	"io"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"helm.sh/helm/v3/pkg/action"
+	"helm.sh/helm/v3/pkg/chartutil"
+	kubefake "helm.sh/helm/v3/pkg/kube/fake"
+	"helm.sh/helm/v3/pkg/release"
+	"helm.sh/helm/v3/pkg/storage"
+	"helm.sh/helm/v3/pkg/storage/driver"
+)
+
+// memoryConfig is Helm configuration with releases kept in memory
+// and objects of charts printed instead of applied.
+func memoryConfig() *action.Configuration {
+	return &action.Configuration{
+		Releases:     storage.Init(driver.NewMemory()),
+		KubeClient:   &kubefake.PrintingKubeClient{Out: io.Discard},
+		Capabilities: chartutil.DefaultCapabilities,
+		Log:          func(string, ...interface{}) {},
+	}
+}
+
+func Test_HelmRelease(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	cfg := memoryConfig()
+
+	chartPath, err := chartutil.Create("vcluster", t.TempDir())
+	require.NoError(t, err)
+
+	_, found, err := releaseStatus(cfg, "test")
+	require.NoError(t, err)
+	assert.False(t, found)
+
+	require.NoError(t, install(ctx, cfg, "test", Config{Chart: chartPath}, map[string]interface{}{"replicaCount": 2}))
+
+	status, found, err := releaseStatus(cfg, "test")
+	require.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, release.StatusDeployed, status)
+
+	rel, err := cfg.Releases.Last("test")
+	require.NoError(t, err)
+	assert.Equal(t, Namespace("test"), rel.Namespace)
+	assert.Equal(t, map[string]interface{}{"replicaCount": 2}, rel.Config)
+
+	require.NoError(t, uninstall(cfg, "test"))
+
+	_, found, err = releaseStatus(cfg, "test")
+	require.NoError(t, err)
+	assert.False(t, found)
+}
+
+func Test_locateChart(t *testing.T) {
+	t.Parallel()
+
+	chartPath, err := chartutil.Create("vcluster", t.TempDir())
+	require.NoError(t, err)
+
+	var opts action.ChartPathOptions
+	vclusterChart, err := locateChart(&opts, Config{Chart: chartPath, ChartRepository: "https://charts.loft.sh"})
+	require.NoError(t, err)
+	assert.Equal(t, "vcluster", vclusterChart.Name())
+	// local charts are not downloaded
+	assert.Empty(t, opts.RepoURL)
+
+	_, err = locateChart(&opts, Config{Chart: filepath.Join(t.TempDir(), "missing.tgz")})
+	assert.Error(t, err)
+
+	// the chart is never downloaded implicitly
+	_, err = locateChart(&opts, Config{ChartVersion: "0.20.0"})
+	require.ErrorIs(t, err, errNoChart)
+	assert.Empty(t, opts.RepoURL)
+}
@@ -0,0 +1,116 @@
+package vcluster
+
+import (
+	"context"
+
+	"github.com/grafana/xk6-environment/pkg/provider"
+
+	"github.com/sirupsen/logrus"
+	"k8s.io/client-go/rest"
+)
+
+// Provider creates environment as a vcluster within the
+// Kubernetes cluster of the current context. It requires vcluster CLI.
+//
+// Deprecated: use HelmProvider, which is the default; Provider is
+// used only if Config.CLI is set.
+type Provider struct {
+	name       string
+	configPath string
+	config     Config
+	logger     logrus.FieldLogger
+}
+
+var (
+	_ provider.Provider         = (*Provider)(nil)
+	_ provider.RESTConfigGetter = (*Provider)(nil)
+	_ provider.ConnectionCloser = (*Provider)(nil)
+)
+
+// NewProvider constructs a vcluster Provider. By default, vcluster is
+// installed with Helm, which requires either a local "chart" or an explicit
+// "chartRepository"; with "cli" set in "vcluster" object of parameters
+// of Environment, it's created with the deprecated vcluster CLI instead.
+// See Config for the rest of "vcluster" object. If vcluster.yaml is present
+// in init folder, it is used as values file unless one is given explicitly.
+func NewProvider(params provider.Params) (provider.Provider, error) {
+	var cfg Config
+
+	if opts, ok := params.Options["vcluster"]; ok {
+		var err error
+		if cfg, err = ParseConfig(opts); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(cfg.ValuesFile) == 0 && params.Env != nil {
+		cfg.ValuesFile = params.Env.VClusterValuesFile
+	}
+
+	if !cfg.CLI {
+		if len(cfg.Chart) == 0 && len(cfg.ChartRepository) == 0 {
+			return nil, errNoChart
+		}
+
+		return &HelmProvider{
+			name:       params.Name,
+			configPath: params.ConfigPath,
+			config:     cfg,
+		}, nil
+	}
+
+	p := &Provider{
+		name:       params.Name,
+		configPath: params.ConfigPath,
+		config:     cfg,
+	}
+	if params.Logger != nil {
+		p.logger = params.Logger.WithField("environment", params.Name)
+		p.logger.Warn("vcluster CLI is deprecated and is going to be removed; " +
+			`vcluster is installed with Helm unless "cli" is set`)
+	}
+
+	return p, nil
+}
+
+// Create creates a vcluster. Output of vcluster CLI is streamed to the k6 logger.
+func (p *Provider) Create(_ context.Context) error {
+	return Create(p.configPath, p.name, p.config, p.logger)
+}
+
+// Delete closes the tunnel to vcluster and removes the vcluster.
+func (p *Provider) Delete(_ context.Context) error {
+	closeTunnel(p.name)
+
+	return Delete(p.configPath, p.name, p.logger)
+}
+
+// RESTConfig returns configuration to access vcluster. vcluster CLI
+// doesn't connect to vcluster, so Kubeconfig of the user is intact.
+func (p *Provider) RESTConfig(ctx context.Context) (*rest.Config, error) {
+	return restConfig(ctx, p.configPath, p.name)
+}
+
+// Closed returns a channel which is closed when the tunnel to vcluster,
+// used by the given configuration, is closed.
+func (p *Provider) Closed(restConfig *rest.Config) <-chan struct{} {
+	return tunnelClosed(p.name, restConfig)
+}
+
+// Status returns the state of vcluster as reported by vcluster CLI.
+func (p *Provider) Status(_ context.Context) (provider.Status, error) {
+	statuses, err := List(p.configPath)
+	if err != nil {
+		return provider.StatusUnknown, err
+	}
+
+	s, ok := statuses[p.name]
+	switch {
+	case !ok:
+		return provider.StatusNotFound, nil
+	case s == "Running":
+		return provider.StatusRunning, nil
+	default:
+		return provider.StatusPending, nil
+	}
+}
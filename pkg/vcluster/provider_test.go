@@ -0,0 +1,110 @@
+package vcluster
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"testing"
+
+	"github.com/grafana/xk6-environment/pkg/kubernetes"
+	"github.com/grafana/xk6-environment/pkg/provider"
+
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/rest"
+)
+
+func Test_NewProvider(t *testing.T) {
+	t.Parallel()
+
+	logger, hook := test.NewNullLogger()
+
+	// the chart is never downloaded implicitly
+	_, err := NewProvider(provider.Params{Name: "test", Logger: logger})
+	require.ErrorIs(t, err, errNoChart)
+
+	for _, opts := range []map[string]interface{}{
+		{"chart": "vcluster-0.20.0.tgz"},
+		{"chartRepository": "https://charts.loft.sh"},
+	} {
+		p, err := NewProvider(provider.Params{
+			Name:    "test",
+			Options: map[string]interface{}{"vcluster": opts},
+			Logger:  logger,
+		})
+		require.NoError(t, err)
+		assert.IsType(t, &HelmProvider{}, p)
+	}
+	assert.Empty(t, hook.AllEntries())
+
+	p, err := NewProvider(provider.Params{
+		Name:    "test",
+		Options: map[string]interface{}{"vcluster": map[string]interface{}{"cli": true}},
+		Logger:  logger,
+	})
+	require.NoError(t, err)
+	assert.IsType(t, &Provider{}, p)
+	// vcluster CLI is deprecated
+	require.Len(t, hook.AllEntries(), 1)
+	assert.Equal(t, logrus.WarnLevel, hook.LastEntry().Level)
+}
+
+const testKubeconfig = `apiVersion: v1
+kind: Config
+clusters:
+- name: vcluster
+  cluster:
+    server: https://localhost:8443
+    insecure-skip-tls-verify: true
+contexts:
+- name: vcluster
+  context:
+    cluster: vcluster
+    user: admin
+current-context: vcluster
+users:
+- name: admin
+  user:
+    token: secret-token
+`
+
+func Test_kubeconfig(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	host, err := kubernetes.NewFakeClient()
+	require.NoError(t, err)
+
+	// secret generated by vcluster
+	require.NoError(t, host.Apply(ctx, bytes.NewBufferString(fmt.Sprintf(`apiVersion: v1
+kind: Secret
+metadata:
+  name: vc-test
+  namespace: vcluster-test
+data:
+  config: %s
+`, base64.StdEncoding.EncodeToString([]byte(testKubeconfig))))))
+
+	restConfig, err := kubeconfig(ctx, host, "test")
+	require.NoError(t, err)
+	assert.Equal(t, "https://localhost:8443", restConfig.Host)
+	assert.Equal(t, "secret-token", restConfig.BearerToken)
+	assert.True(t, restConfig.Insecure)
+
+	_, err = kubeconfig(ctx, host, "missing")
+	assert.True(t, kubernetes.IsNotFound(err))
+}
+
+func Test_tunnelClosed(t *testing.T) {
+	t.Parallel()
+
+	// configuration of a tunnel which is gone
+	select {
+	case <-tunnelClosed("missing", &rest.Config{Host: "https://localhost:8443"}):
+	default:
+		t.Fatal("tunnel is not closed")
+	}
+}